@@ -0,0 +1,60 @@
+package bolog
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+// archiveLayout is the time.Format layout of the subdirectory of LogDir files are archived to by ArchiveByDate.
+const archiveLayout = "2006/01/02"
+
+// archivedPattern matches the paths, relative to LogDir, of archived files.
+var archivedPattern = regexp.MustCompile(`^\d{4}/\d{2}/\d{2}/`)
+
+// archiveByDate moves the log files last written before today into LogDir/2006/01/02,
+// after the day they were last written, if ArchiveByDate is set. The current file is never moved.
+// Rotations not yet passed to onRotate are updated with the new paths.
+// Errors are reported with log.Printf. The lock must be held, unless r is not shared yet.
+func (r *rotatingFile) archiveByDate() {
+	if !r.config.ArchiveByDate {
+		return
+	}
+	files, err := listLogFiles(r.config)
+	if err != nil {
+		log.Printf("Error archiving log files: %v", err)
+		return
+	}
+	today := periodStart(time.Now().In(r.location), "daily")
+	current := filepath.Clean(r.file.Filename)
+	for _, file := range files {
+		rel, err := filepath.Rel(r.config.LogDir, file.Path)
+		if err != nil || filepath.Clean(file.Path) == current || archivedPattern.MatchString(filepath.ToSlash(rel)) {
+			continue
+		}
+		written := file.ModTime.In(r.location)
+		if !written.Before(today) {
+			continue
+		}
+		dir := filepath.Join(r.config.LogDir, filepath.FromSlash(written.Format(archiveLayout)))
+		if err := os.MkdirAll(dir, r.config.DirPerm); err != nil {
+			log.Printf("Error archiving log files: %v", err)
+			return
+		}
+		archived := filepath.Join(dir, filepath.Base(file.Path))
+		if err := os.Rename(file.Path, archived); err != nil {
+			log.Printf("Error archiving log files: %v", err)
+			continue
+		}
+		if _, err := os.Stat(file.Path + ChecksumExt); err == nil {
+			_ = os.Rename(file.Path+ChecksumExt, archived+ChecksumExt)
+		}
+		for i := range r.completed {
+			if r.completed[i].OldFile == file.Path {
+				r.completed[i].OldFile = archived
+			}
+		}
+	}
+}
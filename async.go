@@ -0,0 +1,175 @@
+package bolog
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrAsyncLoggerClosed is returned when writing to or flushing an AsyncLogger after Close.
+var ErrAsyncLoggerClosed = errors.New("bolog: async logger is closed")
+
+// errEntryDropped is returned by asyncWriter for an entry dropped because the buffer is full,
+// so that it is neither counted nor passed to WrittenHooks as written.
+var errEntryDropped = errors.New("bolog: async buffer is full")
+
+// defaultAsyncBufferSize is the queue size of an AsyncLogger created with a bufferSize below 1.
+const defaultAsyncBufferSize = 1024
+
+// AsyncLogger is a Logger whose entries are queued on a buffered channel
+// and written to the file by a background goroutine, so logging never blocks on file I/O.
+// Entries are dropped, and counted, when the buffer is full.
+type AsyncLogger struct {
+	*Logger
+
+	target  *Logger
+	queue   chan asyncItem
+	done    chan struct{}
+	dropped atomic.Uint64
+
+	mu     sync.RWMutex // Guards closed against concurrent sends
+	closed bool
+
+	errMu sync.Mutex
+	err   error // First write error since the last Flush
+}
+
+// asyncItem is either data to write or, when flushed is non-nil, a flush request.
+type asyncItem struct {
+	data    []byte
+	flushed chan struct{}
+}
+
+// NewAsyncLogger returns an AsyncLogger writing to the file of l through a queue holding up to bufferSize entries,
+// or defaultAsyncBufferSize entries if bufferSize is below 1, since an unbuffered queue would drop almost every entry.
+func NewAsyncLogger(l *Logger, bufferSize int) *AsyncLogger {
+	if bufferSize < 1 {
+		bufferSize = defaultAsyncBufferSize
+	}
+	a := &AsyncLogger{
+		target: l,
+		queue:  make(chan asyncItem, bufferSize),
+		done:   make(chan struct{}),
+	}
+	a.Logger = l.derive()
+	a.Logger.out = asyncWriter{a}
+	go a.run()
+	return a
+}
+
+// Write queues a copy of p to be written to the file. It never blocks;
+// if the buffer is full the data is dropped and counted.
+func (a *AsyncLogger) Write(p []byte) (int, error) {
+	data := make([]byte, len(p))
+	copy(data, p)
+	if _, err := a.enqueue(data); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Flush waits until every entry queued so far has been written and flushes the target's buffer.
+// It returns the first write error that occurred since the previous Flush.
+func (a *AsyncLogger) Flush() error {
+	a.mu.RLock()
+	if a.closed {
+		a.mu.RUnlock()
+		return ErrAsyncLoggerClosed
+	}
+	flushed := make(chan struct{})
+	a.queue <- asyncItem{flushed: flushed}
+	a.mu.RUnlock()
+
+	<-flushed
+	return errors.Join(a.takeErr(), a.target.Flush())
+}
+
+// Close drains the queue, stops the background goroutine and closes the underlying file.
+// It implements io.Closer.
+func (a *AsyncLogger) Close() error {
+	a.mu.Lock()
+	if a.closed {
+		a.mu.Unlock()
+		return ErrAsyncLoggerClosed
+	}
+	a.closed = true
+	close(a.queue)
+	a.mu.Unlock()
+
+	<-a.done
+	return errors.Join(a.takeErr(), a.target.Close())
+}
+
+// DroppedCount returns the number of entries dropped because the buffer was full.
+func (a *AsyncLogger) DroppedCount() uint64 {
+	return a.dropped.Load()
+}
+
+// enqueue hands data to the background goroutine without blocking,
+// reporting whether it was queued rather than dropped.
+func (a *AsyncLogger) enqueue(data []byte) (bool, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if a.closed {
+		return false, ErrAsyncLoggerClosed
+	}
+
+	select {
+	case a.queue <- asyncItem{data: data}:
+		return true, nil
+	default:
+		a.dropped.Add(1)
+		return false, nil
+	}
+}
+
+// run writes queued entries until the queue is closed.
+func (a *AsyncLogger) run() {
+	defer close(a.done)
+	for item := range a.queue {
+		if item.flushed != nil {
+			close(item.flushed)
+			continue
+		}
+		if _, err := a.target.out.Write(item.data); err != nil {
+			a.target.reportWriteError(err)
+			a.errMu.Lock()
+			if a.err == nil {
+				a.err = err
+			}
+			a.errMu.Unlock()
+		}
+	}
+}
+
+// takeErr returns and clears the recorded write error.
+func (a *AsyncLogger) takeErr() error {
+	a.errMu.Lock()
+	defer a.errMu.Unlock()
+	err := a.err
+	a.err = nil
+	return err
+}
+
+// asyncWriter lets the embedded Logger hand formatted entries to the queue
+// without copying them, as they are never reused by the caller.
+type asyncWriter struct {
+	a *AsyncLogger
+}
+
+// Write implements io.Writer, returning errEntryDropped if the buffer is full.
+func (w asyncWriter) Write(p []byte) (int, error) {
+	queued, err := w.a.enqueue(p)
+	if err != nil {
+		return 0, err
+	}
+	if !queued {
+		return 0, errEntryDropped
+	}
+	return len(p), nil
+}
+
+// Flush waits for the queue to drain, so fatal entries are written before the process exits.
+func (w asyncWriter) Flush() error {
+	return w.a.Flush()
+}
@@ -0,0 +1,156 @@
+package bolog
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ErrAuditChainBroken is returned by VerifyAuditLog, and by NewAuditLogger for an existing file,
+// when an entry of an audit log does not carry the HMAC expected from the entries before it.
+var ErrAuditChainBroken = errors.New("bolog: audit log chain is broken")
+
+// auditTimeLayout formats audit timestamps, in UTC, with a fixed width so that
+// the timestamp and message covered by an HMAC cannot be told apart differently.
+const auditTimeLayout = "2006-01-02T15:04:05.000000000Z"
+
+// AuditEntry is an entry of an audit log, as written by AuditLogger and returned by VerifyAuditLog.
+type AuditEntry struct {
+	Time    time.Time `json:"time"`
+	Message string    `json:"message"`
+	HMAC    string    `json:"hmac"` // Hex HMAC-SHA256 of the previous entry's HMAC, the timestamp and the message
+}
+
+// AuditLogger appends tamper-evident entries to an audit log, a file of JSON lines each carrying
+// an HMAC that chains it to the previous entry, so that any change, removal or reordering of entries
+// is detected by VerifyAuditLog. The file is never rotated or deleted by bolog.
+type AuditLogger struct {
+	mu   sync.Mutex
+	file *os.File
+	key  []byte
+	prev []byte // HMAC of the last entry, empty before the first
+}
+
+// NewAuditLogger opens the audit log at path for appending, creating it and its directory
+// with the DirPerm and FilePerm of l if needed. The entries of an existing file are verified
+// and the chain continues from the last one; a broken chain is returned as ErrAuditChainBroken.
+func (l *Logger) NewAuditLogger(path string, hmacKey []byte) (*AuditLogger, error) {
+	if len(hmacKey) == 0 {
+		return nil, errors.New("bolog: audit HMAC key must not be empty")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), l.config().DirPerm); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_APPEND|os.O_CREATE, l.config().FilePerm)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := readAuditChain(file, hmacKey)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	a := &AuditLogger{file: file, key: append([]byte(nil), hmacKey...)}
+	if len(entries) > 0 {
+		a.prev, _ = hex.DecodeString(entries[len(entries)-1].HMAC)
+	}
+	return a, nil
+}
+
+// Audit appends message to the audit log and syncs the file, so that the entry is on disk when it returns.
+func (a *AuditLogger) Audit(message string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.file == nil {
+		return os.ErrClosed
+	}
+
+	now := time.Now().UTC()
+	mac := auditMAC(a.key, a.prev, now, message)
+	line, err := json.Marshal(AuditEntry{Time: now, Message: message, HMAC: hex.EncodeToString(mac)})
+	if err != nil {
+		return err
+	}
+	if _, err := a.file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	if err := a.file.Sync(); err != nil {
+		return err
+	}
+	a.prev = mac
+	return nil
+}
+
+// Auditf formats a message and appends it to the audit log like Audit.
+func (a *AuditLogger) Auditf(format string, v ...interface{}) error {
+	return a.Audit(fmt.Sprintf(format, v...))
+}
+
+// Close closes the audit log. It implements io.Closer.
+func (a *AuditLogger) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.file == nil {
+		return os.ErrClosed
+	}
+	err := a.file.Close()
+	a.file = nil
+	return err
+}
+
+// VerifyAuditLog replays the HMAC chain of the audit log at path written with hmacKey.
+// It returns the entries in order, or those before the first entry that breaks the chain
+// together with an error wrapping ErrAuditChainBroken that gives its line.
+func VerifyAuditLog(path string, hmacKey []byte) ([]AuditEntry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return readAuditChain(file, hmacKey)
+}
+
+// readAuditChain reads and verifies the audit entries of r.
+func readAuditChain(r io.Reader, key []byte) ([]AuditEntry, error) {
+	reader := bufio.NewReader(r)
+	var entries []AuditEntry
+	var prev []byte
+	for line := 1; ; line++ {
+		data, err := reader.ReadBytes('\n')
+		if err == io.EOF && len(data) == 0 {
+			return entries, nil
+		}
+		if err != nil && err != io.EOF {
+			return entries, err
+		}
+		var entry AuditEntry
+		if err := json.Unmarshal(bytes.TrimSpace(data), &entry); err != nil {
+			return entries, fmt.Errorf("%w at line %d: %v", ErrAuditChainBroken, line, err)
+		}
+		mac, err := hex.DecodeString(entry.HMAC)
+		if err != nil || !hmac.Equal(mac, auditMAC(key, prev, entry.Time, entry.Message)) {
+			return entries, fmt.Errorf("%w at line %d", ErrAuditChainBroken, line)
+		}
+		entries = append(entries, entry)
+		prev = mac
+	}
+}
+
+// auditMAC returns the HMAC-SHA256 with key of prev, the timestamp t and message.
+func auditMAC(key, prev []byte, t time.Time, message string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write(prev)
+	h.Write([]byte(t.UTC().Format(auditTimeLayout)))
+	h.Write([]byte(message))
+	return h.Sum(nil)
+}
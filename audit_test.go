@@ -0,0 +1,151 @@
+package bolog_test
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Lacolle87/bolog"
+)
+
+var auditKey = []byte("audit-test-key")
+
+// writeAuditLog audits messages to a new audit log and returns its path.
+func writeAuditLog(t *testing.T, messages ...string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "audit", "audit.log")
+	appendAuditLog(t, path, messages...)
+	return path
+}
+
+// appendAuditLog audits messages to the audit log at path, continuing its chain.
+func appendAuditLog(t *testing.T, path string, messages ...string) {
+	t.Helper()
+	a, err := bolog.NewWriterLogger(io.Discard).NewAuditLogger(path, auditKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, msg := range messages {
+		if err := a.Audit(msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := a.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAuditLogRoundTrip(t *testing.T) {
+	tests := []struct {
+		name    string
+		batches [][]string // Messages audited by successive AuditLoggers on the same file
+	}{
+		{name: "empty", batches: [][]string{nil}},
+		{name: "single logger", batches: [][]string{{"user created", "role granted", "user deleted"}}},
+		{name: "resumed chain", batches: [][]string{{"user created"}, {"role granted", "user deleted"}}},
+		{name: "resumed empty chain", batches: [][]string{nil, {"user created"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "audit.log")
+			var want []string
+			for _, batch := range tt.batches {
+				appendAuditLog(t, path, batch...)
+				want = append(want, batch...)
+			}
+
+			entries, err := bolog.VerifyAuditLog(path, auditKey)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(entries) != len(want) {
+				t.Fatalf("verified %d entries, want %d", len(entries), len(want))
+			}
+			for i, e := range entries {
+				if e.Message != want[i] || e.HMAC == "" || e.Time.IsZero() {
+					t.Errorf("entry %d = %+v, want message %q", i, e, want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestAuditLogTampering(t *testing.T) {
+	tests := []struct {
+		name    string
+		tamper  func(lines []string) []string
+		key     []byte
+		entries int // Entries verified before the break
+	}{
+		{
+			name: "edited message",
+			tamper: func(lines []string) []string {
+				lines[1] = strings.Replace(lines[1], "granted", "revoked", 1)
+				return lines
+			},
+			entries: 1,
+		},
+		{
+			name:    "removed entry",
+			tamper:  func(lines []string) []string { return append(lines[:1], lines[2:]...) },
+			entries: 1,
+		},
+		{
+			name:    "reordered entries",
+			tamper:  func(lines []string) []string { lines[0], lines[1] = lines[1], lines[0]; return lines },
+			entries: 0,
+		},
+		{
+			name:    "malformed entry",
+			tamper:  func(lines []string) []string { lines[2] = "{" + lines[2]; return lines },
+			entries: 2,
+		},
+		{name: "wrong key", key: []byte("another-key")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeAuditLog(t, "user created", "role granted", "user deleted")
+			if tt.tamper != nil {
+				data, err := os.ReadFile(path)
+				if err != nil {
+					t.Fatal(err)
+				}
+				lines := tt.tamper(strings.Split(strings.TrimSuffix(string(data), "\n"), "\n"))
+				if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			key := auditKey
+			if tt.key != nil {
+				key = tt.key
+			}
+
+			entries, err := bolog.VerifyAuditLog(path, key)
+			if !errors.Is(err, bolog.ErrAuditChainBroken) {
+				t.Fatalf("VerifyAuditLog() error = %v, want ErrAuditChainBroken", err)
+			}
+			if len(entries) != tt.entries {
+				t.Errorf("verified %d entries, want %d", len(entries), tt.entries)
+			}
+			if _, err := bolog.NewWriterLogger(io.Discard).NewAuditLogger(path, key); !errors.Is(err, bolog.ErrAuditChainBroken) {
+				t.Errorf("NewAuditLogger() error = %v, want ErrAuditChainBroken", err)
+			}
+		})
+	}
+}
+
+func TestAuditLoggerClosed(t *testing.T) {
+	a, err := bolog.NewWriterLogger(io.Discard).NewAuditLogger(writeAuditLog(t), auditKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Audit("too late"); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("Audit() after Close error = %v, want os.ErrClosed", err)
+	}
+}
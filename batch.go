@@ -0,0 +1,145 @@
+package bolog
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrBatcherClosed is returned when writing to or flushing a Batcher after Close.
+var ErrBatcherClosed = errors.New("bolog: batcher is closed")
+
+// Batcher is a Logger that accumulates formatted entries in memory and writes them to the output
+// of its target as one block, once maxSize entries are pending or flushInterval has passed,
+// so that backends such as HTTPBackend receive few large writes instead of many small ones.
+type Batcher struct {
+	*Logger
+
+	target  *Logger
+	maxSize int
+	stop    chan struct{}
+	done    chan struct{}
+
+	mu      sync.Mutex // Guards the fields below and orders the writes of blocks
+	pending bytes.Buffer
+	count   int
+	closed  bool
+}
+
+// NewBatcher returns a Batcher writing to the output of l in blocks of up to maxSize entries,
+// and at least every flushInterval if it is positive.
+func NewBatcher(l *Logger, maxSize int, flushInterval time.Duration) *Batcher {
+	if maxSize < 1 {
+		maxSize = 1
+	}
+	b := &Batcher{
+		target:  l,
+		maxSize: maxSize,
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	b.Logger = l.derive()
+	b.Logger.out = batchWriter{b}
+	go b.run(flushInterval)
+	return b
+}
+
+// Write adds a copy of p to the pending block, writing the block if it holds maxSize entries.
+func (b *Batcher) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return 0, ErrBatcherClosed
+	}
+	b.pending.Write(p)
+	b.count++
+	if b.count >= b.maxSize {
+		if err := b.writePending(); err != nil {
+			return len(p), err
+		}
+	}
+	return len(p), nil
+}
+
+// Flush writes the pending entries right away and flushes the target's buffer.
+func (b *Batcher) Flush() error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return ErrBatcherClosed
+	}
+	err := b.writePending()
+	b.mu.Unlock()
+	return errors.Join(err, b.target.Flush())
+}
+
+// Close writes the pending entries, stops the background flushes and closes the target.
+// It implements io.Closer.
+func (b *Batcher) Close() error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return ErrBatcherClosed
+	}
+	b.closed = true
+	err := b.writePending()
+	b.mu.Unlock()
+
+	close(b.stop)
+	<-b.done
+	return errors.Join(err, b.target.Close())
+}
+
+// writePending writes the pending entries to the target as one block, with the lock held.
+// Write errors are reported to OnWriteError of the target and returned; the entries are not kept.
+func (b *Batcher) writePending() error {
+	if b.count == 0 {
+		return nil
+	}
+	_, err := b.target.out.Write(b.pending.Bytes())
+	b.pending.Reset()
+	b.count = 0
+	if err != nil {
+		b.target.reportWriteError(err)
+	}
+	return err
+}
+
+// run writes the pending entries every interval until Close.
+func (b *Batcher) run(interval time.Duration) {
+	defer close(b.done)
+	if interval <= 0 {
+		<-b.stop
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			b.mu.Lock()
+			if !b.closed {
+				_ = b.writePending()
+			}
+			b.mu.Unlock()
+		case <-b.stop:
+			return
+		}
+	}
+}
+
+// batchWriter lets the embedded Logger hand formatted entries to the Batcher.
+type batchWriter struct {
+	b *Batcher
+}
+
+// Write implements io.Writer.
+func (w batchWriter) Write(p []byte) (int, error) {
+	return w.b.Write(p)
+}
+
+// Flush writes the pending entries, so fatal entries are written before the process exits.
+func (w batchWriter) Flush() error {
+	return w.b.Flush()
+}
@@ -1,78 +1,670 @@
 package bolog
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
+	"unicode/utf8"
 
 	"github.com/natefinch/lumberjack"
 )
 
 // ConfigLogger defines the configuration structure for the logger.
 type ConfigLogger struct {
-	LogDir     string `json:"logDir"`     // Directory for storing logs
-	MaxSize    int    `json:"maxsize"`    // Maximum log file size in megabytes
-	MaxBackups int    `json:"maxbackups"` // Maximum number of old log files to retain
-	MaxAge     int    `json:"maxage"`     // Maximum number of days to retain old log files
-	Compress   bool   `json:"compress"`   // Compress old log files
-	Timezone   string `json:"timezone"`   // Timezone
+	LogDir            string                           `json:"logDir" yaml:"logDir" toml:"logDir"`                                  // Directory for storing logs
+	MaxSize           int                              `json:"maxsize" yaml:"maxsize" toml:"maxsize"`                               // Maximum log file size in megabytes
+	MaxBackups        int                              `json:"maxbackups" yaml:"maxbackups" toml:"maxbackups"`                      // Maximum number of old log files to retain
+	MaxAge            int                              `json:"maxage" yaml:"maxage" toml:"maxage"`                                  // Maximum number of days to retain old log files
+	Compress          bool                             `json:"compress" yaml:"compress" toml:"compress"`                            // Compress old log files
+	Timezone          string                           `json:"timezone" yaml:"timezone" toml:"timezone"`                            // Timezone
+	Level             string                           `json:"level" yaml:"level" toml:"level"`                                     // Minimum level to write, defaults to "INFO"
+	Format            string                           `json:"format" yaml:"format" toml:"format"`                                  // Output format, "text" (default), "json", "ndjson", "gelf", "logfmt" or "csv"
+	CallerDepth       int                              `json:"callerdepth" yaml:"callerdepth" toml:"callerdepth"`                   // Stack frames above the logging call to report as caller, 0 disables
+	RotateInterval    string                           `json:"rotateinterval" yaml:"rotateinterval" toml:"rotateinterval"`          // Start a new file "hourly", "daily", "weekly" or "monthly", in addition to size-based rotation, defaults to the interval of RotateBy
+	FilenameTemplate  string                           `json:"filenametemplate" yaml:"filenametemplate" toml:"filenametemplate"`    // time.Format layout of log file names, defaults to "log_20060102.txt"
+	TimestampFormat   string                           `json:"timestampformat" yaml:"timestampformat" toml:"timestampformat"`       // time.Format layout of entry timestamps or "unixms", defaults to "2006-01-02 15:04:05"
+	SequenceNumbers   bool                             `json:"sequencenumbers" yaml:"sequencenumbers" toml:"sequencenumbers"`       // Number entries from 1, restarting in every new file
+	IncludeHostname   bool                             `json:"includehostname" yaml:"includehostname" toml:"includehostname"`       // Add the host name to every entry
+	IncludePID        bool                             `json:"includepid" yaml:"includepid" toml:"includepid"`                      // Add the process ID to every entry
+	BufferSize        int                              `json:"buffersize" yaml:"buffersize" toml:"buffersize"`                      // Bytes buffered in memory before writing to the file, 0 disables buffering
+	DedupeWindow      time.Duration                    `json:"dedupewindow" yaml:"dedupewindow" toml:"dedupewindow"`                // Suppress consecutive identical messages within this window, 0 disables
+	Syslog            SyslogConfig                     `json:"syslog" yaml:"syslog" toml:"syslog"`                                  // Syslog destination used by the syslogbackend package
+	MaxTotalMB        int                              `json:"maxtotalmb" yaml:"maxtotalmb" toml:"maxtotalmb"`                      // Maximum total megabytes of log files, oldest backups are deleted beyond it, 0 disables
+	CurrentSymlink    string                           `json:"currentsymlink" yaml:"currentsymlink" toml:"currentsymlink"`          // Name of a symlink in LogDir kept pointing to the current file, empty disables
+	MaxMessageBytes   int                              `json:"maxmessagebytes" yaml:"maxmessagebytes" toml:"maxmessagebytes"`       // Messages longer than this are truncated and marked "...[truncated]", 0 disables
+	IncludeElapsed    bool                             `json:"includeelapsed" yaml:"includeelapsed" toml:"includeelapsed"`          // Add the time since the logger was created to every entry
+	OnWriteError      func(err error)                  `json:"-" yaml:"-" toml:"-"`                                                 // Called with every write error instead of reporting it with log.Printf
+	WriteRetries      int                              `json:"writeretries" yaml:"writeretries" toml:"writeretries"`                // Times a failed write to the log file is retried, 0 disables
+	WriteRetryDelay   time.Duration                    `json:"writeretrydelay" yaml:"writeretrydelay" toml:"writeretrydelay"`       // Pause between write retries
+	WriteTimeout      time.Duration                    `json:"writetimeout" yaml:"writetimeout" toml:"writetimeout"`                // Time after which a write is abandoned, and a failing one no longer retried, 0 for no limit
+	RotateBy          string                           `json:"rotateby" yaml:"rotateby" toml:"rotateby"`                            // File naming scheme, "daily" (default), "hourly", "monthly" or "weekday"
+	FileNamer         FileNamer                        `json:"-" yaml:"-" toml:"-"`                                                 // Custom file naming strategy, replacing FilenameTemplate and RotateBy
+	FileHeader        func(config ConfigLogger) string `json:"-" yaml:"-" toml:"-"`                                                 // Generates a header written at the top of every new file, such as DefaultFileHeader
+	DirPerm           os.FileMode                      `json:"dirperm" yaml:"dirperm" toml:"dirperm"`                               // Permissions of the created log directories, defaults to 0755
+	FilePerm          os.FileMode                      `json:"fileperm" yaml:"fileperm" toml:"fileperm"`                            // Permissions of new log files, defaults to 0644
+	CompressOnWrite   bool                             `json:"compressonwrite" yaml:"compressonwrite" toml:"compressonwrite"`       // Gzip the active file as it is written, naming it ".log.gz"; MaxSize then counts uncompressed bytes
+	CompressAlgo      string                           `json:"compressalgo" yaml:"compressalgo" toml:"compressalgo"`                // Compression of backups, "gzip" (default) or "zstd", named ".gz" and ".zst"
+	Checksum          bool                             `json:"checksum" yaml:"checksum" toml:"checksum"`                            // Write the SHA-256 digest of every completed file to a ".sha256" file next to it, see VerifyChecksum
+	EncryptionKey     []byte                           `json:"-" yaml:"-" toml:"-"`                                                 // AES-256 key, 32 bytes, encrypting every entry with AES-GCM, see NewDecryptingReader
+	MaxLines          int                              `json:"maxlines" yaml:"maxlines" toml:"maxlines"`                            // Rotate once a file holds this many lines, in addition to MaxSize, 0 for no limit
+	ArchiveByDate     bool                             `json:"archivebydate" yaml:"archivebydate" toml:"archivebydate"`             // Move files from previous days to LogDir/2006/01/02 on startup and time-based rotation
+	AdditionalLogDirs []string                         `json:"additionallogdirs" yaml:"additionallogdirs" toml:"additionallogdirs"` // Directories that receive a copy of every entry, best-effort, e.g. an NFS mount
+	SampleRate        int                              `json:"samplerate" yaml:"samplerate" toml:"samplerate"`                      // Write only every Nth entry if greater than 1
+	OpenMode          string                           `json:"openmode" yaml:"openmode" toml:"openmode"`                            // How to open the log file on startup, "append", "new" or "rotate"
+	LocalBackupTime   bool                             `json:"localbackuptime" yaml:"localbackuptime" toml:"localbackuptime"`       // Name rotated backups with the time in Timezone instead of UTC
+	ExclusiveLock     bool                             `json:"exclusivelock" yaml:"exclusivelock" toml:"exclusivelock"`             // Hold an advisory lock on the log files so that no other process writes to them
+	LockTimeout       time.Duration                    `json:"locktimeout" yaml:"locktimeout" toml:"locktimeout"`                   // Time to wait for the lock of ExclusiveLock, 0 to try once
+}
+
+// SyslogConfig defines where the syslogbackend package sends entries.
+type SyslogConfig struct {
+	Network  string `json:"network" yaml:"network" toml:"network"`    // "udp", "tcp" or "unix", empty for the local syslog daemon
+	Addr     string `json:"addr" yaml:"addr" toml:"addr"`             // Address of the syslog server, empty for the local syslog daemon
+	Priority string `json:"priority" yaml:"priority" toml:"priority"` // "facility.severity" such as "local0.info", defaults to "user.info"
+	Tag      string `json:"tag" yaml:"tag" toml:"tag"`                // Tag of every message, defaults to the program name
 }
 
 // Logger is a wrapper around lumberjack.Logger.
+// Loggers derived with WithField, WithFields or WithPrefix share the same lumberjack.Logger.
 type Logger struct {
-	lumberjack.Logger
-	config ConfigLogger
+	*lumberjack.Logger
+	core   *core
+	prefix string
+	out    io.Writer // Destination of formatted entries, the rotatingFile unless wrapped
+
+	fieldsMu sync.RWMutex // Guards fields, which SetFields and AddField replace
+	fields   Fields       // Never modified in place
+
+	limiter    *rateLimiter    // Set by WithRateLimit
+	transforms []Transform     // Set by NewPipeline, applied in order to every entry
+	ctx        context.Context // Set by ForRequest, the Context of its entries
+	tenant     string          // Set by ForTenant
+}
+
+// core holds the state shared by a logger and the loggers derived from it.
+type core struct {
+	level    atomic.Int32
+	closed   atomic.Bool                  // Set by Shutdown, after which entries are rejected
+	disabled atomic.Bool                  // Set by Disable, silencing all output
+	config   atomic.Pointer[ConfigLogger] // Replaced by ReloadConfig, never modified in place
+
+	configPath string                      // File the configuration was loaded from, if any
+	reloadMu   sync.Mutex                  // Serializes ReloadConfig
+	file       *rotatingFile               // Log file shared by every derived logger
+	backend    io.Closer                   // Output set by NewWriterLogger, closed by Close
+	lock       *os.File                    // Lock file held with ExclusiveLock, released by Close
+	fileless   bool                        // Set by NewWriterLogger, whose log file is never used
+	dedupe     deduper                     // Repetition state for DedupeWindow
+	sampler    sampler                     // Counters for SampleRate
+	counts     atomic.Pointer[levelCounts] // Entries written per level, replaced by ResetCounts
+	location   *time.Location              // Cached Timezone, which cannot change after construction
+	started    time.Time                   // Construction time, the origin of Entry.Elapsed
+	hostname   string                      // Cached host name, set if IncludeHostname is enabled
+	pid        int                         // Cached process ID, set if IncludePID is enabled
+	clones     atomic.Int64                // Number of clones made by Clone, numbering their files
+
+	mu        sync.RWMutex
+	formatter Formatter
+	hooks     []Hook             // Replaced, never modified in place, so it can be read without the lock
+	providers []fieldProvider    // Registered by DynamicFields, replaced like hooks
+	events    chan RotationEvent // Created by RotationEvents
 }
 
 // InitializeLoggerFromConfig reads a configuration file and initializes a logger.
 // It returns a pointer to the initialized Logger or an error if the process fails.
 func InitializeLoggerFromConfig(configFile string) (*Logger, error) {
-	loggerConfig, err := LoadLoggerConfig(configFile)
+	loggerConfig, err := loadConfigFile(configFile, configFormat(configFile))
+	if err != nil {
+		return nil, err
+	}
+	logger, err := SetupLogger(loggerConfig)
 	if err != nil {
 		return nil, err
 	}
+	logger.core.configPath = configFile
+	return logger, nil
+}
 
-	return SetupLogger(loggerConfig), nil
+// InitializeLoggerFromReader decodes a configuration in the given format, "json", "yaml" or "toml",
+// from r and initializes a logger, e.g. from an environment variable with strings.NewReader.
+// Since there is no file to re-read, ReloadConfig and WatchConfig return ErrNoConfigFile for the logger.
+func InitializeLoggerFromReader(r io.Reader, format string) (*Logger, error) {
+	loggerConfig, err := LoadLoggerConfigFromReader(r, format)
+	if err != nil {
+		return nil, err
+	}
+	return SetupLogger(loggerConfig)
 }
 
 // SetupLogger creates the log directory and initializes a lumberjack.Logger with the specified configurations.
-// It returns a pointer to the initialized Logger.
-func SetupLogger(config ConfigLogger) *Logger {
-	err := os.MkdirAll(config.LogDir, os.ModePerm)
+// Zero-valued fields are replaced by the values from DefaultConfig, except Compress.
+// It returns a pointer to the initialized Logger or an error if the log directory cannot be created.
+func SetupLogger(config ConfigLogger) (*Logger, error) {
+	return openLogger(config.withDefaults())
+}
+
+// SetupLoggerOrFatal is like SetupLogger but calls log.Fatal if the log directory cannot be created.
+//
+// Deprecated: Use SetupLogger and handle the error.
+func SetupLoggerOrFatal(config ConfigLogger) *Logger {
+	logger, err := SetupLogger(config)
 	if err != nil {
 		log.Fatal(err)
 	}
+	return logger
+}
 
-	logPath := filepath.Join(config.LogDir, getLogFileName(config.Timezone))
-	return &Logger{
-		Logger: lumberjack.Logger{
-			Filename:   logPath,
-			MaxSize:    config.MaxSize,
-			MaxBackups: config.MaxBackups,
-			MaxAge:     config.MaxAge,
-			Compress:   config.Compress,
+// NewLogger builds a configuration by applying the given options to DefaultConfig,
+// creates the log directory and initializes a lumberjack.Logger with it.
+// It returns a pointer to the initialized Logger, calling log.Fatal if the log directory cannot be created;
+// use SetupLogger to handle that error instead.
+func NewLogger(opts ...Option) *Logger {
+	config := DefaultConfig()
+	for _, opt := range opts {
+		opt(&config)
+	}
+	logger, err := openLogger(config.withDefaults())
+	if err != nil {
+		log.Fatal(err)
+	}
+	return logger
+}
+
+// openLogger creates the log directory and a logger writing to the log file described by config.
+func openLogger(config ConfigLogger) (*Logger, error) {
+	if len(config.EncryptionKey) > 0 {
+		if _, err := newAEAD(config.EncryptionKey); err != nil {
+			return nil, err
+		}
+	}
+	if err := os.MkdirAll(config.LogDir, config.DirPerm); err != nil {
+		return nil, err
+	}
+	var lock *os.File
+	if config.ExclusiveLock {
+		var err error
+		if lock, err = acquireLock(config); err != nil {
+			return nil, err
+		}
+	}
+	logger := newLogger(config)
+	logger.core.lock = lock
+	logger.core.file.onMirrorError = logger.reportWriteError
+	logger.core.file.addMirrors(config)
+	logger.core.file.discardStale()
+	logger.core.file.archiveByDate()
+	logger.core.file.updateSymlink()
+	if strings.ToLower(config.OpenMode) == "rotate" {
+		if info, err := os.Stat(logger.core.file.filename()); err == nil && info.Size() > 0 {
+			if err := logger.core.file.RotateNow(); err != nil {
+				// Release the lock and the files, so that opening the logger can be retried.
+				return nil, errors.Join(err, logger.Close())
+			}
+		}
+	}
+	return logger, nil
+}
+
+// openTimeLayout formats the start time appended to the file names with OpenMode "new".
+const openTimeLayout = "150405"
+
+// NewWriterLogger creates a logger that writes formatted entries to w instead of a log file,
+// configured like NewLogger except that the file settings are unused.
+// If w implements io.Closer, Close closes it. Rotate does nothing.
+func NewWriterLogger(w io.Writer, opts ...Option) *Logger {
+	config := DefaultConfig()
+	for _, opt := range opts {
+		opt(&config)
+	}
+	logger := newLogger(config.withDefaults())
+	logger.out = w
+	logger.core.fileless = true
+	if closer, ok := w.(io.Closer); ok {
+		logger.core.backend = closer
+	}
+	return logger
+}
+
+// newLogger creates a logger writing to the log file described by config, without creating LogDir.
+func newLogger(config ConfigLogger) *Logger {
+	// The file name, the rotation period and entry timestamps all derive from the same instant
+	// and location, so the first entries of a file created near midnight fall on the day it is named after.
+	location := getTimezone(config.Timezone)
+	now := time.Now().In(location)
+	if strings.ToLower(config.OpenMode) == "new" {
+		config.FileNamer = suffixNamer{base: fileNamer(config), suffix: "-" + now.Format(openTimeLayout)}
+	}
+	file := newLumberjack(config, filepath.Join(config.LogDir, getLogFileName(config, now)))
+	rotating := newRotatingFile(file, config, now)
+	logger := &Logger{
+		Logger: file,
+		core: &core{
+			formatter: getFormatter(config),
+			file:      rotating,
+			location:  location,
+			started:   now,
 		},
-		config: config,
+		out: rotating,
+	}
+	logger.core.config.Store(&config)
+	logger.core.counts.Store(new(levelCounts))
+	rotating.onPreRotate = logger.core.preRotate
+	rotating.onRotate = logger.core.rotated
+	rotating.header = formatterHeader(logger.core.formatter)
+	if config.IncludeHostname {
+		logger.core.hostname, _ = os.Hostname()
+	}
+	if config.IncludePID {
+		logger.core.pid = os.Getpid()
 	}
+	logger.SetLevel(getLevel(config.Level))
+	return logger
 }
 
-// Logf logs a formatted message with the current time and timezone from the configuration.
+// newLumberjack returns the lumberjack.Logger writing to filename with the file settings of config.
+func newLumberjack(config ConfigLogger, filename string) *lumberjack.Logger {
+	return &lumberjack.Logger{
+		Filename:   filename,
+		MaxSize:    config.MaxSize,
+		MaxBackups: config.MaxBackups,
+		MaxAge:     config.MaxAge,
+		LocalTime:  config.LocalBackupTime,
+		Compress:   config.Compress,
+	}
+}
+
+// SetLevel changes the minimum level of entries written by the logger and the loggers derived from it.
+// It is safe to call while other goroutines are logging.
+func (l *Logger) SetLevel(level Level) {
+	l.core.level.Store(int32(level))
+}
+
+// Level returns the current minimum level of the logger.
+func (l *Logger) Level() Level {
+	return Level(l.core.level.Load())
+}
+
+// Disable silences the logger and the loggers derived from it, independently of the level,
+// until Enable is called. Write methods return immediately without allocating.
+func (l *Logger) Disable() {
+	l.core.disabled.Store(true)
+}
+
+// Enable resumes writing after Disable.
+func (l *Logger) Enable() {
+	l.core.disabled.Store(false)
+}
+
+// IsEnabled reports whether the logger writes entries, i.e. Disable has not been called.
+func (l *Logger) IsEnabled() bool {
+	return !l.core.disabled.Load()
+}
+
+// enabled reports whether an entry at level would be written.
+func (l *Logger) enabled(level Level) bool {
+	return level >= l.Level() && l.IsEnabled()
+}
+
+// SetFormatter replaces the Formatter used to render entries, e.g. with a custom implementation.
+// The change also applies to the loggers derived from l.
+func (l *Logger) SetFormatter(formatter Formatter) {
+	l.core.mu.Lock()
+	l.core.formatter = formatter
+	l.core.mu.Unlock()
+	l.core.file.setHeader(formatterHeader(formatter))
+}
+
+// formatterHeader returns the file header of formatter, nil unless it is a HeaderFormatter.
+func formatterHeader(formatter Formatter) []byte {
+	if hf, ok := formatter.(HeaderFormatter); ok {
+		return hf.Header()
+	}
+	return nil
+}
+
+// config returns the configuration currently in use, which must not be modified.
+func (l *Logger) config() *ConfigLogger {
+	return l.core.config.Load()
+}
+
+// formatter returns the Formatter currently in use.
+func (l *Logger) formatter() Formatter {
+	l.core.mu.RLock()
+	defer l.core.mu.RUnlock()
+	return l.core.formatter
+}
+
+// textFormat reports whether the logger writes the plain text format, where annotations
+// go in the message rather than in fields.
+func (l *Logger) textFormat() bool {
+	switch l.formatter().(type) {
+	case TextFormatter, *TextFormatter:
+		return true
+	}
+	return false
+}
+
+// WithField returns a logger that adds the key-value pair to every entry it writes.
+func (l *Logger) WithField(key string, value interface{}) *Logger {
+	return l.WithFields(Fields{key: value})
+}
+
+// WithFields returns a logger that adds fields to every entry it writes, on top of l's own fields.
+// The returned logger writes to the same file as l.
+func (l *Logger) WithFields(fields Fields) *Logger {
+	child := l.derive()
+	child.fields = mergeFields(child.fields, fields)
+	return child
+}
+
+// WithPrefix returns a logger that prepends prefix to the message of every entry it writes.
+// Prefixes of nested loggers are joined with a space. The returned logger writes to the same file as l.
+func (l *Logger) WithPrefix(prefix string) *Logger {
+	child := l.derive()
+	if l.prefix != "" {
+		prefix = l.prefix + " " + prefix
+	}
+	child.prefix = prefix
+	return child
+}
+
+// derive returns a copy of l sharing its file, configuration and core.
+func (l *Logger) derive() *Logger {
+	return &Logger{
+		Logger: l.Logger,
+		core:   l.core,
+		fields: l.persistentFields(),
+		prefix: l.prefix,
+		out:    l.out,
+
+		limiter:    l.limiter,
+		transforms: l.transforms,
+		ctx:        l.ctx,
+		tenant:     l.tenant,
+	}
+}
+
+// Logf logs a formatted message at InfoLevel with the current time and timezone from the configuration.
 func (l *Logger) Logf(format string, v ...interface{}) {
-	currentTime := time.Now().In(getTimezone(l.config.Timezone))
-	message := fmt.Sprintf("[%s] -- "+format, append([]interface{}{currentTime.Format("2006-01-02 15:04:05")}, v...)...)
+	l.logf(InfoLevel, format, v...)
+}
+
+// Debugf logs a formatted message at DebugLevel.
+func (l *Logger) Debugf(format string, v ...interface{}) {
+	l.logf(DebugLevel, format, v...)
+}
 
-	if _, err := l.Write([]byte(message + "\n")); err != nil {
-		log.Printf("Error writing log: %v", err)
+// Infof logs a formatted message at InfoLevel.
+func (l *Logger) Infof(format string, v ...interface{}) {
+	l.logf(InfoLevel, format, v...)
+}
+
+// Warnf logs a formatted message at WarnLevel.
+func (l *Logger) Warnf(format string, v ...interface{}) {
+	l.logf(WarnLevel, format, v...)
+}
+
+// Errorf logs a formatted message at ErrorLevel.
+func (l *Logger) Errorf(format string, v ...interface{}) {
+	l.logf(ErrorLevel, format, v...)
+}
+
+// Fatalf logs a formatted message at FatalLevel, closes the log file and then calls os.Exit(1).
+func (l *Logger) Fatalf(format string, v ...interface{}) {
+	l.logf(FatalLevel, format, v...)
+	l.exit()
+}
+
+// LogInfo logs msg unformatted at InfoLevel.
+func (l *Logger) LogInfo(msg string) {
+	if l.enabled(InfoLevel) {
+		l.writeEntry(InfoLevel, msg, nil)
+	}
+}
+
+// logf writes a formatted message tagged with the given level,
+// skipping it if the level is below the logger's minimum level.
+func (l *Logger) logf(level Level, format string, v ...interface{}) {
+	if !l.enabled(level) {
+		return
+	}
+	l.writeEntry(level, fmt.Sprintf(format, v...), nil)
+}
+
+// writeEntry formats an entry carrying the logger's fields merged with extra and writes it to the log file.
+func (l *Logger) writeEntry(level Level, message string, extra Fields) error {
+	return l.write(Entry{Level: level, Message: message, Fields: extra})
+}
+
+// write completes entry with the prefix, the tenant and the logger's fields, drops it if it exceeds the rate limit,
+// is sampled out by SampleRate or repeats the previous message within DedupeWindow,
+// and otherwise formats it and writes it to the log file.
+// The entry's own fields take precedence over those of DynamicFields, which take precedence over the logger's.
+// Formatting and write errors are passed to OnWriteError, or reported with log.Printf if it is nil, and returned.
+func (l *Logger) write(entry Entry) error {
+	if l.core.closed.Load() {
+		return ErrLoggerClosed
+	}
+	config := l.config()
+	if l.limiter != nil && !l.limiter.allow() {
+		return nil
+	}
+	if config.SampleRate > 1 && !l.core.sampler.keep(config.SampleRate) {
+		return nil
+	}
+	if dynamic := l.dynamicFields(); dynamic != nil {
+		entry.Fields = mergeFields(dynamic, entry.Fields)
+	}
+	if fields := l.persistentFields(); len(entry.Fields) > 0 {
+		entry.Fields = mergeFields(fields, entry.Fields)
+	} else {
+		entry.Fields = fields
+	}
+	if l.prefix != "" {
+		entry.Message = l.prefix + " " + entry.Message
+	}
+	if l.tenant != "" {
+		entry = l.tagTenant(entry)
+	}
+	if config.MaxMessageBytes > 0 {
+		entry.Message = truncateMessage(entry.Message, config.MaxMessageBytes)
+	}
+	if config.DedupeWindow > 0 {
+		summary, suppress := l.core.dedupe.check(entry, config.DedupeWindow)
+		if summary != nil {
+			_ = l.emit(*summary)
+		}
+		if suppress {
+			return nil
+		}
+	}
+	return l.emit(entry)
+}
+
+// truncatedSuffix marks a message cut short by MaxMessageBytes.
+const truncatedSuffix = "...[truncated]"
+
+// truncateMessage cuts message to at most max bytes, without splitting a UTF-8 sequence,
+// and appends truncatedSuffix if anything was removed.
+func truncateMessage(message string, max int) string {
+	if len(message) <= max {
+		return message
+	}
+	cut := max
+	for cut > 0 && !utf8.RuneStart(message[cut]) {
+		cut--
+	}
+	return message[:cut] + truncatedSuffix
+}
+
+// emit completes entry with the time and caller, unless already set, formats it and writes it.
+func (l *Logger) emit(entry Entry) error {
+	config := l.config()
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+	entry.Time = entry.Time.In(l.core.location)
+	entry.Hostname = l.core.hostname
+	entry.PID = l.core.pid
+	if config.IncludeElapsed {
+		entry.Elapsed = entry.Time.Sub(l.core.started)
+	}
+	if config.CallerDepth > 0 && entry.Caller == "" {
+		entry.Caller = callerLocation(config.CallerDepth)
+	}
+	if entry.Context == nil {
+		entry.Context = l.ctx
+	}
+	for _, transform := range l.transforms {
+		entry = transform(entry)
+	}
+	hooks := l.hooks()
+	for _, hook := range hooks {
+		if transform, ok := hook.(TransformHook); ok {
+			entry = transform.Transform(entry)
+		}
+	}
+	// Entries written straight to the file are numbered under its lock, so that numbering
+	// restarts exactly at rotation; otherwise they are numbered in the order they are logged.
+	numbered := config.SequenceNumbers && l.out == io.Writer(l.core.file)
+	if config.SequenceNumbers && !numbered {
+		entry.Seq = l.core.file.nextSeq()
+	}
+
+	for _, hook := range hooks {
+		if err := hook.Fire(entry); err != nil {
+			log.Printf("Error firing log hook: %v", err)
+		}
+	}
+
+	formatter := l.formatter()
+	var n int
+	var err error
+	if numbered {
+		n, err = l.core.file.writeNumbered(func(seq uint64) ([]byte, error) {
+			entry.Seq = seq
+			return formatter.Format(entry)
+		})
+	} else {
+		var data []byte
+		if data, err = formatter.Format(entry); err == nil {
+			n, err = l.out.Write(data)
+		}
+	}
+	if errors.Is(err, errEntryDropped) {
+		// Dropped by a full AsyncLogger, which counts it in DroppedCount.
+		return nil
+	}
+	if err != nil {
+		l.reportWriteError(err)
+		return err
+	}
+	l.core.count(entry.Level)
+	for _, hook := range hooks {
+		if written, ok := hook.(WrittenHook); ok {
+			written.Written(entry, n)
+		}
+	}
+	return nil
+}
+
+// reportWriteError passes err to OnWriteError, or reports it with log.Printf if there is no callback.
+func (l *Logger) reportWriteError(err error) {
+	if l.config().OnWriteError != nil {
+		l.config().OnWriteError(err)
+		return
+	}
+	log.Printf("Error writing log: %v", err)
+}
+
+// Write writes p unchanged to the logger's output, bypassing formatting.
+// It shadows lumberjack.Logger.Write so raw writes honour time-based rotation and wrappers such as Tee.
+// Nothing is written while the logger is disabled, and ErrLoggerClosed is returned after Shutdown.
+func (l *Logger) Write(p []byte) (int, error) {
+	if l.core.closed.Load() {
+		return 0, ErrLoggerClosed
+	}
+	if !l.IsEnabled() {
+		return len(p), nil
+	}
+	return l.out.Write(p)
+}
+
+// Rotate completes the current log file immediately and calls every PostRotateHook.
+// The next entry goes to a new file, named after the current date if FilenameTemplate yields a new name.
+// It shadows lumberjack.Logger.Rotate so that the logger keeps track of the current file.
+func (l *Logger) Rotate() error {
+	if l.core.fileless {
+		return nil
+	}
+	return l.core.file.RotateNow()
+}
+
+// ErrNoLogFile is returned by SetOutput for loggers created with NewWriterLogger.
+var ErrNoLogFile = errors.New("bolog: logger does not write to a log file")
+
+// SetOutput closes the current log file and continues writing to filename, creating its directory if needed.
+// The other settings, such as MaxSize and MaxBackups, are kept. With a RotateInterval the file
+// named after FilenameTemplate is used again from the next time-based rotation.
+func (l *Logger) SetOutput(filename string) error {
+	if l.core.fileless {
+		return ErrNoLogFile
 	}
+	if err := os.MkdirAll(filepath.Dir(filename), l.config().DirPerm); err != nil {
+		return err
+	}
+	return l.core.file.reopen(filename)
 }
 
-// getLogFileName generates a log file name based on the current date and timezone.
-func getLogFileName(timezone string) string {
-	currentTime := time.Now().In(getTimezone(timezone))
-	return currentTime.Format("log_20060102.txt")
+// Flush writes entries buffered in memory, when BufferSize is set, to the log file.
+func (l *Logger) Flush() error {
+	return l.flushOutput()
+}
+
+// Close writes the summary of suppressed repeats, flushes buffered entries and closes the log file.
+// It shadows lumberjack.Logger.Close so that no buffered entry is lost.
+func (l *Logger) Close() error {
+	if summary := l.core.dedupe.flush(); summary != nil {
+		_ = l.emit(*summary)
+	}
+	flushErr := l.flushOutput()
+	if l.core.backend != nil {
+		return errors.Join(flushErr, l.core.backend.Close(), l.core.file.Close())
+	}
+	return errors.Join(flushErr, l.core.file.Close(), l.core.unlock())
+}
+
+// exit flushes pending entries, closes the log file and terminates the process.
+func (l *Logger) exit() {
+	_ = l.flushOutput()
+	_ = l.Close()
+	os.Exit(1)
+}
+
+// flushOutput writes out entries buffered by the output, if it buffers at all.
+func (l *Logger) flushOutput() error {
+	if flusher, ok := l.out.(interface{ Flush() error }); ok {
+		return flusher.Flush()
+	}
+	return nil
+}
+
+// getLogFileName generates a log file name, relative to LogDir, for a file opened at now
+// using the naming strategy of config. With CompressOnWrite its extension is replaced by ".log.gz".
+func getLogFileName(config ConfigLogger, now time.Time) string {
+	name := fileNamer(config).FileName(now)
+	if config.CompressOnWrite {
+		name = strings.TrimSuffix(name, filepath.Ext(name)) + compressedLogExt
+	}
+	return name
 }
 
 // getTimezone returns a time.Location object for the specified timezone,
@@ -85,24 +677,35 @@ func getTimezone(timezone string) *time.Location {
 	return loc
 }
 
-// LoadLoggerConfig reads and decodes a JSON configuration file into a ConfigLogger struct.
+// LoadLoggerConfig reads and decodes a configuration file into a ConfigLogger struct.
+// Files with a .yaml or .yml extension are decoded as YAML, .toml as TOML, anything else as JSON.
 func LoadLoggerConfig(configPath string) (ConfigLogger, error) {
+	return loadConfigFile(configPath, configFormat(configPath))
+}
+
+// loadConfigFile opens configPath and decodes it into a ConfigLogger struct using the given format.
+// Decoding errors are returned as a *ConfigError carrying the path.
+func loadConfigFile(configPath, format string) (ConfigLogger, error) {
 	file, err := os.Open(configPath)
 	if err != nil {
 		return ConfigLogger{}, err
 	}
-	defer func(file *os.File) {
-		// Close the file and handle any potential errors
-		err := file.Close()
-		if err != nil {
-			log.Fatal("Error closing the file:", err)
+	return decodeConfigFile(file, configPath, format)
+}
+
+// decodeConfigFile decodes the opened configuration file at configPath using the given format and closes it.
+// An error closing the file is returned if decoding succeeded.
+func decodeConfigFile(file io.ReadCloser, configPath, format string) (config ConfigLogger, err error) {
+	defer func(file io.ReadCloser) {
+		// Close the file and report the error unless an earlier one is returned
+		if closeErr := file.Close(); closeErr != nil && err == nil {
+			config, err = ConfigLogger{}, fmt.Errorf("closing config file %s: %w", configPath, closeErr)
 		}
 	}(file)
 
-	var config ConfigLogger
-	err = json.NewDecoder(file).Decode(&config)
+	config, err = LoadLoggerConfigFromReader(file, format)
 	if err != nil {
-		return ConfigLogger{}, err
+		return ConfigLogger{}, newConfigError(configPath, err)
 	}
 
 	return config, nil
@@ -0,0 +1,156 @@
+package bolog_test
+
+import (
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/Lacolle87/bolog"
+)
+
+// benchFormats are the formats every Logf benchmark runs with.
+var benchFormats = []string{"text", "json"}
+
+// benchLogger returns a logger formatting entries in format and discarding them,
+// so that benchmarks measure the logger rather than disk I/O.
+// NopLogger would measure nothing: it is disabled, so entries are dropped before they are formatted.
+func benchLogger(format string, opts ...bolog.Option) *bolog.Logger {
+	return bolog.NewWriterLogger(io.Discard, append([]bolog.Option{bolog.WithFormat(format)}, opts...)...)
+}
+
+// BenchmarkLogfSerial logs from a single goroutine.
+func BenchmarkLogfSerial(b *testing.B) {
+	for _, format := range benchFormats {
+		b.Run(format, func(b *testing.B) {
+			l := benchLogger(format)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				l.Logf("request %d served in %s", i, "12ms")
+			}
+		})
+	}
+}
+
+// BenchmarkLogfParallel logs from GOMAXPROCS goroutines.
+func BenchmarkLogfParallel(b *testing.B) {
+	benchmarkLogfParallel(b, 1)
+}
+
+// BenchmarkLogfHighContention logs from GOMAXPROCS*4 goroutines.
+func BenchmarkLogfHighContention(b *testing.B) {
+	benchmarkLogfParallel(b, 4)
+}
+
+// benchmarkLogfParallel logs from parallelism*GOMAXPROCS goroutines sharing one logger.
+func benchmarkLogfParallel(b *testing.B, parallelism int) {
+	for _, format := range benchFormats {
+		b.Run(format, func(b *testing.B) {
+			l := benchLogger(format)
+			b.ReportAllocs()
+			b.SetParallelism(parallelism)
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for i := 0; pb.Next(); i++ {
+					l.Logf("request %d served in %s", i, "12ms")
+				}
+			})
+		})
+	}
+}
+
+var (
+	benchErr  = errors.New("connection refused")
+	benchLine = []byte("raw line\n")
+)
+
+// disabledCalls are the write methods that must not allocate on a disabled logger.
+// They are called on the logger returned by prepare, if set, made once beforehand.
+var disabledCalls = []struct {
+	name    string
+	prepare func(l *bolog.Logger) *bolog.Logger
+	log     func(l *bolog.Logger)
+}{
+	{"Logf", nil, func(l *bolog.Logger) { l.Logf("request %s served in %d ms", "abc", 12) }},
+	{"Debugf", nil, func(l *bolog.Logger) { l.Debugf("request %s served in %d ms", "abc", 12) }},
+	{"Infof", nil, func(l *bolog.Logger) { l.Infof("request %s served in %d ms", "abc", 12) }},
+	{"Warnf", nil, func(l *bolog.Logger) { l.Warnf("request %s served in %d ms", "abc", 12) }},
+	{"Errorf", nil, func(l *bolog.Logger) { l.Errorf("request %s served in %d ms", "abc", 12) }},
+	{"Print", nil, func(l *bolog.Logger) { l.Print("request served") }},
+	{"Println", nil, func(l *bolog.Logger) { l.Println("request served") }},
+	{"LogInfo", nil, func(l *bolog.Logger) { l.LogInfo("request served") }},
+	{"LogError", nil, func(l *bolog.Logger) { l.LogError(benchErr) }},
+	{"LogErrorf", nil, func(l *bolog.Logger) { l.LogErrorf(benchErr, "request %s failed", "abc") }},
+	{"LogEvent", nil, func(l *bolog.Logger) {
+		l.LogEvent(bolog.LogEvent{Level: bolog.InfoLevel, Message: "request served"})
+	}},
+	{"Chain", nil, func(l *bolog.Logger) { l.Info().Str("request", "abc").Int("ms", 12).Msg("request served") }},
+	{"WithFields", func(l *bolog.Logger) *bolog.Logger {
+		return l.WithFields(bolog.Fields{"request": "abc"})
+	}, func(l *bolog.Logger) { l.Infof("request served in %d ms", 12) }},
+	{"Write", nil, func(l *bolog.Logger) { _, _ = l.Write(benchLine) }},
+}
+
+// disabledLogger returns a text logger silenced with Disable, derived with prepare if it is set.
+func disabledLogger(prepare func(l *bolog.Logger) *bolog.Logger) *bolog.Logger {
+	l := benchLogger("text")
+	l.Disable()
+	if prepare != nil {
+		l = prepare(l)
+	}
+	return l
+}
+
+// BenchmarkDisabled measures the write methods of a disabled logger, failing if they allocate.
+func BenchmarkDisabled(b *testing.B) {
+	for _, call := range disabledCalls {
+		b.Run(call.name, func(b *testing.B) {
+			l := disabledLogger(call.prepare)
+			if allocs := testing.AllocsPerRun(100, func() { call.log(l) }); allocs != 0 {
+				b.Fatalf("%s allocates %v times per call on a disabled logger", call.name, allocs)
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				call.log(l)
+			}
+		})
+	}
+}
+
+// TestDisabledAllocs checks that every write method returns without allocating on a disabled logger.
+func TestDisabledAllocs(t *testing.T) {
+	for _, call := range disabledCalls {
+		l := disabledLogger(call.prepare)
+		if allocs := testing.AllocsPerRun(100, func() { call.log(l) }); allocs != 0 {
+			t.Errorf("%s allocates %v times per call on a disabled logger", call.name, allocs)
+		}
+	}
+}
+
+// BenchmarkLogfTimezone logs with a non-UTC Timezone, whose location is cached when the logger is created.
+// The lookup sub-benchmark measures the time.LoadLocation call each entry used to make, for comparison.
+func BenchmarkLogfTimezone(b *testing.B) {
+	const timezone = "America/New_York"
+	if _, err := time.LoadLocation(timezone); err != nil {
+		b.Skipf("time zone database unavailable: %v", err)
+	}
+	for _, format := range benchFormats {
+		b.Run(format, func(b *testing.B) {
+			l := benchLogger(format, bolog.WithTimezone(timezone))
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				l.Logf("request %d served in %s", i, "12ms")
+			}
+		})
+	}
+	b.Run("lookup", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			location, _ := time.LoadLocation(timezone)
+			_ = time.Now().In(location)
+		}
+	})
+}
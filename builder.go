@@ -0,0 +1,78 @@
+package bolog
+
+import "reflect"
+
+// ConfigBuilder layers configuration sources over DefaultConfig, later sources taking precedence,
+// e.g. a base file, then environment variables, then overrides from code.
+// The first error from any source is returned by Build.
+type ConfigBuilder struct {
+	config ConfigLogger
+	err    error
+}
+
+// NewConfigBuilder returns a ConfigBuilder starting from DefaultConfig.
+func NewConfigBuilder() *ConfigBuilder {
+	return &ConfigBuilder{config: DefaultConfig()}
+}
+
+// FromFile merges the non-zero fields of the configuration file at path, read with LoadLoggerConfig.
+func (b *ConfigBuilder) FromFile(path string) *ConfigBuilder {
+	if b.err != nil {
+		return b
+	}
+	config, err := LoadLoggerConfig(path)
+	if err != nil {
+		b.err = err
+		return b
+	}
+	mergeConfig(&b.config, config)
+	return b
+}
+
+// FromEnv merges the fields for which an environment variable named after prefix is set,
+// as read by LoadLoggerConfigFromEnv.
+func (b *ConfigBuilder) FromEnv(prefix string) *ConfigBuilder {
+	if b.err != nil {
+		return b
+	}
+	b.err = applyEnv(&b.config, prefix)
+	return b
+}
+
+// Override merges the non-zero fields of c. Since false cannot be told apart from unset,
+// a bool field such as Compress can only be switched on this way, not off.
+func (b *ConfigBuilder) Override(c ConfigLogger) *ConfigBuilder {
+	if b.err != nil {
+		return b
+	}
+	mergeConfig(&b.config, c)
+	return b
+}
+
+// Build returns the merged configuration after validating it.
+func (b *ConfigBuilder) Build() (ConfigLogger, error) {
+	if b.err != nil {
+		return ConfigLogger{}, b.err
+	}
+	if err := b.config.Validate(); err != nil {
+		return ConfigLogger{}, err
+	}
+	return b.config, nil
+}
+
+// mergeConfig copies the non-zero fields of src over dst, recursing into nested structs such as Syslog.
+func mergeConfig(dst *ConfigLogger, src ConfigLogger) {
+	mergeStruct(reflect.ValueOf(dst).Elem(), reflect.ValueOf(src))
+}
+
+func mergeStruct(dst, src reflect.Value) {
+	for i := 0; i < src.NumField(); i++ {
+		field := src.Field(i)
+		switch {
+		case field.Kind() == reflect.Struct:
+			mergeStruct(dst.Field(i), field)
+		case !field.IsZero():
+			dst.Field(i).Set(field)
+		}
+	}
+}
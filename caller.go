@@ -0,0 +1,32 @@
+package bolog
+
+import (
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// packagePrefix identifies the frames belonging to this package, which are never reported as caller.
+var packagePrefix = reflect.TypeOf(Logger{}).PkgPath() + "."
+
+// callerLocation returns "file.go:line" of the frame depth levels above the first frame
+// outside this package, or an empty string if the stack is not that deep.
+func callerLocation(depth int) string {
+	pcs := make([]uintptr, 16+depth)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, packagePrefix) {
+			depth--
+			if depth == 0 {
+				return filepath.Base(frame.File) + ":" + strconv.Itoa(frame.Line)
+			}
+		}
+		if !more {
+			return ""
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package bolog
+
+import "sync"
+
+// Chain builds an entry field by field, in the style of zerolog:
+//
+//	logger.Info().Str("user", name).Int("attempt", n).Msg("logged in")
+//
+// Chains are obtained from Logger.Debug, Info, Warn and Error and reused once Msg or Send is called,
+// so a Chain must not be used after that. Methods on the nil Chain returned for disabled levels do nothing.
+type Chain struct {
+	logger *Logger
+	level  Level
+	fields Fields
+}
+
+var chainPool = sync.Pool{New: func() interface{} { return new(Chain) }}
+
+// Debug starts an entry at DebugLevel.
+func (l *Logger) Debug() *Chain {
+	return l.chain(DebugLevel)
+}
+
+// Info starts an entry at InfoLevel.
+func (l *Logger) Info() *Chain {
+	return l.chain(InfoLevel)
+}
+
+// Warn starts an entry at WarnLevel.
+func (l *Logger) Warn() *Chain {
+	return l.chain(WarnLevel)
+}
+
+// Error starts an entry at ErrorLevel.
+func (l *Logger) Error() *Chain {
+	return l.chain(ErrorLevel)
+}
+
+// chain returns a pooled Chain for level, or nil if level is disabled.
+func (l *Logger) chain(level Level) *Chain {
+	if !l.enabled(level) {
+		return nil
+	}
+	c := chainPool.Get().(*Chain)
+	c.logger = l
+	c.level = level
+	return c
+}
+
+// Str adds a string field.
+func (c *Chain) Str(key, value string) *Chain {
+	return c.field(key, value)
+}
+
+// Int adds an integer field.
+func (c *Chain) Int(key string, value int) *Chain {
+	return c.field(key, value)
+}
+
+// Bool adds a boolean field.
+func (c *Chain) Bool(key string, value bool) *Chain {
+	return c.field(key, value)
+}
+
+// Any adds a field of any type.
+func (c *Chain) Any(key string, value interface{}) *Chain {
+	return c.field(key, value)
+}
+
+// Err adds err as the "error" field. A nil error is ignored.
+func (c *Chain) Err(err error) *Chain {
+	if err == nil {
+		return c
+	}
+	return c.field(ErrorKey, err.Error())
+}
+
+// Msg writes the entry with msg as its message and releases the Chain.
+func (c *Chain) Msg(msg string) {
+	if c == nil {
+		return
+	}
+	c.logger.write(Entry{Level: c.level, Message: msg, Fields: c.fields})
+	// The fields now belong to the entry, which hooks may keep.
+	c.logger = nil
+	c.fields = nil
+	chainPool.Put(c)
+}
+
+// Send writes the entry with an empty message and releases the Chain.
+func (c *Chain) Send() {
+	c.Msg("")
+}
+
+// field adds key to the fields of the entry.
+func (c *Chain) field(key string, value interface{}) *Chain {
+	if c == nil {
+		return c
+	}
+	if c.fields == nil {
+		c.fields = make(Fields, 4)
+	}
+	c.fields[key] = value
+	return c
+}
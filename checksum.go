@@ -0,0 +1,61 @@
+package bolog
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ChecksumExt is the extension added to a completed log file to name its checksum file.
+const ChecksumExt = ".sha256"
+
+// ErrChecksumMismatch is returned by VerifyChecksum when a log file does not match its checksum file.
+var ErrChecksumMismatch = errors.New("bolog: log file does not match its checksum")
+
+// VerifyChecksum recomputes the SHA-256 digest of logfile and compares it to the one recorded
+// in logfile+".sha256" when it was completed with Checksum enabled.
+// It returns ErrChecksumMismatch if they differ.
+func VerifyChecksum(logfile string) error {
+	data, err := os.ReadFile(logfile + ChecksumExt)
+	if err != nil {
+		return err
+	}
+	want, _, _ := strings.Cut(strings.TrimSpace(string(data)), " ")
+	got, err := fileDigest(logfile)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(got, want) {
+		return fmt.Errorf("%w: %s", ErrChecksumMismatch, logfile)
+	}
+	return nil
+}
+
+// writeChecksum writes the digest of file to file+".sha256" with permissions perm, in the format of sha256sum.
+func writeChecksum(file string, perm os.FileMode) error {
+	digest, err := fileDigest(file)
+	if err != nil {
+		return err
+	}
+	line := digest + "  " + filepath.Base(file) + "\n"
+	return os.WriteFile(file+ChecksumExt, []byte(line), perm)
+}
+
+// fileDigest returns the hex SHA-256 digest of the contents of file.
+func fileDigest(file string) (string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
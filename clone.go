@@ -0,0 +1,75 @@
+package bolog
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Clone creates an independent logger with the configuration of l, changed by opts,
+// and its own log file that rotates separately. Unless opts change where the files go,
+// the clone's files are named like l's with "-N" inserted before the extension, N counting the clones of l.
+// The clone starts with the level, formatter, hooks, dynamic fields, fields, prefix and tenant of l; later changes to either logger
+// do not affect the other. It returns ErrNoLogFile for loggers created with NewWriterLogger.
+func (l *Logger) Clone(opts ...Option) (*Logger, error) {
+	if l.core.fileless {
+		return nil, ErrNoLogFile
+	}
+	config := l.core.file.settings()
+	config.Level = l.Level().String()
+	for _, opt := range opts {
+		opt(&config)
+	}
+	config = config.withDefaults()
+
+	now := time.Now().In(l.core.location)
+	if filepath.Join(config.LogDir, getLogFileName(config, now)) == l.core.file.filename() {
+		suffix := "-" + strconv.FormatInt(l.core.clones.Add(1), 10)
+		config.FileNamer = suffixNamer{base: fileNamer(config), suffix: suffix}
+	}
+
+	clone, err := openLogger(config)
+	if err != nil {
+		return nil, err
+	}
+	clone.fields = l.persistentFields()
+	clone.prefix = l.prefix
+	clone.tenant = l.tenant
+	clone.transforms = l.transforms
+	clone.SetFormatter(l.formatter())
+	for _, hook := range l.hooks() {
+		clone.AddHook(hook)
+	}
+	for _, provider := range l.fieldProviders() {
+		clone.DynamicFields(provider)
+	}
+	return clone, nil
+}
+
+// suffixNamer is a FileNamer inserting suffix before the extension of the names of base.
+type suffixNamer struct {
+	base   FileNamer
+	suffix string
+}
+
+// FileName implements FileNamer.
+func (n suffixNamer) FileName(t time.Time) string {
+	name := n.base.FileName(t)
+	ext := filepath.Ext(name)
+	return strings.TrimSuffix(name, ext) + n.suffix + ext
+}
+
+// builtinNamer reports whether namer, with any suffix added by Clone, is one of the built-in naming schemes.
+func builtinNamer(namer FileNamer) bool {
+	for {
+		switch n := namer.(type) {
+		case nil, TemplateNamer, isoWeekNamer:
+			return true
+		case suffixNamer:
+			namer = n.base
+		default:
+			return false
+		}
+	}
+}
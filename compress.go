@@ -0,0 +1,162 @@
+package bolog
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// compressedLogExt is the extension of the log files written with CompressOnWrite.
+const compressedLogExt = ".log.gz"
+
+// gzipTailReader reads a gzip stream that may still be written to, as the current file with CompressOnWrite,
+// treating a last member that has not been closed yet as the end of the data.
+type gzipTailReader struct {
+	gz *gzip.Reader
+}
+
+// Read implements io.Reader.
+func (r gzipTailReader) Read(p []byte) (int, error) {
+	n, err := r.gz.Read(p)
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		err = io.EOF
+	}
+	return n, err
+}
+
+// compressedExt returns the extension of backups compressed with algo, "gzip" or "zstd".
+func compressedExt(algo string) string {
+	if algo == "zstd" {
+		return ".zst"
+	}
+	return ".gz"
+}
+
+// isCompressed reports whether path names a gzip or zstd compressed file.
+func isCompressed(path string) bool {
+	return strings.HasSuffix(path, ".gz") || strings.HasSuffix(path, ".zst")
+}
+
+// decompress returns a reader of the contents of src, read from the file at path,
+// decompressed according to its extension, and a function releasing the decompressor.
+// Gzip streams are read with gzipTailReader. Other files are read as is.
+func decompress(path string, src io.Reader) (io.Reader, func(), error) {
+	switch {
+	case strings.HasSuffix(path, ".gz"):
+		gz, err := gzip.NewReader(src)
+		if err != nil {
+			return nil, nil, err
+		}
+		return gzipTailReader{gz}, func() { gz.Close() }, nil
+	case strings.HasSuffix(path, ".zst"):
+		dec, err := zstd.NewReader(src)
+		if err != nil {
+			return nil, nil, err
+		}
+		return dec, dec.Close, nil
+	}
+	return src, func() {}, nil
+}
+
+// pruneZstdBackups removes the zstd-compressed backups of the current file beyond MaxBackups
+// or older than MaxAge, since lumberjack only recognizes uncompressed and gzip-compressed backups.
+// Errors are reported with log.Printf.
+func (r *rotatingFile) pruneZstdBackups() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file.MaxBackups <= 0 && r.file.MaxAge <= 0 {
+		return
+	}
+
+	name := r.file.Filename
+	ext := filepath.Ext(name)
+	prefix := strings.TrimSuffix(filepath.Base(name), ext) + "-"
+	entries, err := os.ReadDir(filepath.Dir(name))
+	if err != nil {
+		log.Printf("Error removing old log files: %v", err)
+		return
+	}
+	location := r.backupLocation()
+	type backup struct {
+		path string
+		time time.Time
+	}
+	var backups []backup
+	for _, entry := range entries {
+		stamp, ok := strings.CutPrefix(entry.Name(), prefix)
+		if !ok || len(stamp) < len(backupTimeFormat) {
+			continue
+		}
+		switch stamp[len(backupTimeFormat):] {
+		case ext, ext + ".gz", ext + ".zst":
+		default:
+			continue
+		}
+		t, err := time.ParseInLocation(backupTimeFormat, stamp[:len(backupTimeFormat)], location)
+		if err != nil {
+			continue
+		}
+		backups = append(backups, backup{path: filepath.Join(filepath.Dir(name), entry.Name()), time: t})
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].time.After(backups[j].time)
+	})
+
+	cutoff := time.Now().Add(-time.Duration(r.file.MaxAge) * 24 * time.Hour)
+	for i, b := range backups {
+		if !strings.HasSuffix(b.path, ".zst") {
+			continue
+		}
+		if (r.file.MaxBackups > 0 && i >= r.file.MaxBackups) || (r.file.MaxAge > 0 && b.time.Before(cutoff)) {
+			if err := os.Remove(b.path); err != nil && !os.IsNotExist(err) {
+				log.Printf("Error removing old log files: %v", err)
+			}
+		}
+	}
+}
+
+// compressFile compresses src with algo, "gzip" or "zstd", into a file with the extension of algo
+// added and the same permissions, and removes src. It returns the path of the compressed file.
+func compressFile(src, algo string) (string, error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return "", err
+	}
+	defer in.Close()
+	info, err := in.Stat()
+	if err != nil {
+		return "", err
+	}
+
+	dst := src + compressedExt(algo)
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, info.Mode())
+	if err != nil {
+		return "", err
+	}
+	var enc io.WriteCloser
+	if algo == "zstd" {
+		enc, err = zstd.NewWriter(out)
+	} else {
+		enc = gzip.NewWriter(out)
+	}
+	if err == nil {
+		_, err = io.Copy(enc, in)
+		err = errors.Join(err, enc.Close())
+	}
+	if err = errors.Join(err, out.Close()); err != nil {
+		_ = os.Remove(dst)
+		return "", err
+	}
+	if err := in.Close(); err != nil {
+		return "", err
+	}
+	return dst, os.Remove(src)
+}
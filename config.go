@@ -0,0 +1,105 @@
+package bolog
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v2"
+)
+
+// ConfigError reports a configuration file that could not be decoded.
+type ConfigError struct {
+	Path string // Path of the configuration file
+	Line int    // Line of the error, or 0 if the decoder does not report it
+	Err  error  // Underlying decoder error
+}
+
+// Error implements the error interface.
+func (e *ConfigError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("%s:%d: %v", e.Path, e.Line, e.Err)
+	}
+	return fmt.Sprintf("%s: %v", e.Path, e.Err)
+}
+
+// Unwrap returns the underlying decoder error.
+func (e *ConfigError) Unwrap() error {
+	return e.Err
+}
+
+// newConfigError wraps a decoding error of the file at path,
+// extracting the line number when the decoder provides one.
+func newConfigError(path string, err error) *ConfigError {
+	configErr := &ConfigError{Path: path, Err: err}
+	var parseErr toml.ParseError
+	if errors.As(err, &parseErr) {
+		configErr.Line = parseErr.Position.Line
+	}
+	return configErr
+}
+
+// LoadLoggerConfigFromReader decodes a configuration in the given format, "json", "yaml" or "toml",
+// from r into a ConfigLogger struct.
+func LoadLoggerConfigFromReader(r io.Reader, format string) (ConfigLogger, error) {
+	var config ConfigLogger
+	var err error
+	switch strings.ToLower(format) {
+	case "json":
+		err = json.NewDecoder(r).Decode(&config)
+	case "yaml", "yml":
+		err = yaml.NewDecoder(r).Decode(&config)
+	case "toml":
+		_, err = toml.NewDecoder(r).Decode(&config)
+	default:
+		err = fmt.Errorf("unsupported config format %q", format)
+	}
+	if err != nil {
+		return ConfigLogger{}, err
+	}
+
+	return config, nil
+}
+
+// LoadLoggerConfigJSON reads and decodes a JSON configuration file into a ConfigLogger struct.
+func LoadLoggerConfigJSON(configPath string) (ConfigLogger, error) {
+	return loadConfigFile(configPath, "json")
+}
+
+// LoadLoggerConfigYAML reads and decodes a YAML configuration file into a ConfigLogger struct.
+func LoadLoggerConfigYAML(configPath string) (ConfigLogger, error) {
+	return loadConfigFile(configPath, "yaml")
+}
+
+// LoadLoggerConfigTOML reads and decodes a TOML configuration file into a ConfigLogger struct.
+// Parse errors are returned as a *ConfigError carrying the offending line.
+func LoadLoggerConfigTOML(configPath string) (ConfigLogger, error) {
+	return loadConfigFile(configPath, "toml")
+}
+
+// LoadLoggerConfigFromFS reads and decodes the configuration file at path in fsys, such as an embed.FS,
+// detecting the format from the file extension like LoadLoggerConfig.
+func LoadLoggerConfigFromFS(fsys fs.FS, path string) (ConfigLogger, error) {
+	file, err := fsys.Open(path)
+	if err != nil {
+		return ConfigLogger{}, err
+	}
+	return decodeConfigFile(file, path, configFormat(path))
+}
+
+// configFormat returns the config format implied by the extension of path, defaulting to "json".
+func configFormat(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return "yaml"
+	case ".toml":
+		return "toml"
+	default:
+		return "json"
+	}
+}
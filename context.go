@@ -0,0 +1,88 @@
+package bolog
+
+import (
+	"context"
+	"fmt"
+)
+
+// Well-known context field keys.
+const (
+	RequestIDKey = "request_id"
+	TraceIDKey   = "trace_id"
+	SpanIDKey    = "span_id"
+	TenantIDKey  = "tenant_id"
+)
+
+// contextKey is the type of the key under which bolog stores fields in a context,
+// so they cannot collide with keys defined by other packages.
+type contextKey struct{}
+
+// fieldsContextKey is the key of the Fields stored by NewContextWithField.
+var fieldsContextKey = contextKey{}
+
+// NewContextWithField returns a copy of ctx that carries the key-value pair,
+// in addition to any fields already stored in ctx.
+func NewContextWithField(ctx context.Context, key string, value interface{}) context.Context {
+	existing := FieldsFromContext(ctx)
+	fields := make(Fields, len(existing)+1)
+	for k, v := range existing {
+		fields[k] = v
+	}
+	fields[key] = value
+	return context.WithValue(ctx, fieldsContextKey, fields)
+}
+
+// FieldsFromContext returns the fields stored in ctx by NewContextWithField, or nil if there are none.
+// The returned map must not be modified.
+func FieldsFromContext(ctx context.Context) Fields {
+	if ctx == nil {
+		return nil
+	}
+	fields, _ := ctx.Value(fieldsContextKey).(Fields)
+	return fields
+}
+
+// LogfCtx logs a formatted message at InfoLevel, appending the fields stored in ctx
+// such as the request or trace ID.
+func (l *Logger) LogfCtx(ctx context.Context, format string, v ...interface{}) {
+	if !l.enabled(InfoLevel) {
+		return
+	}
+	l.write(Entry{Level: InfoLevel, Message: fmt.Sprintf(format, v...), Fields: FieldsFromContext(ctx), Context: ctx})
+}
+
+// correlationKey is the key of the correlation ID stored by WithCorrelationID.
+type correlationKey struct{}
+
+// WithCorrelationID returns a copy of ctx carrying id as the request-scoped correlation ID.
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationKey{}, id)
+}
+
+// CorrelationIDFromContext returns the correlation ID stored in ctx by WithCorrelationID, if any.
+func CorrelationIDFromContext(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+	id, ok := ctx.Value(correlationKey{}).(string)
+	return id, ok && id != ""
+}
+
+// ForRequest returns a logger for the request of ctx, adding the fields stored in ctx to every entry
+// and starting every message with "[corr:ID]" if ctx carries a correlation ID,
+// ahead of any prefix and fields. Its entries carry ctx as their Context.
+// The returned logger writes to the same file as l.
+func (l *Logger) ForRequest(ctx context.Context) *Logger {
+	child := l.derive()
+	child.ctx = ctx
+	if fields := FieldsFromContext(ctx); len(fields) > 0 {
+		child.fields = mergeFields(child.fields, fields)
+	}
+	if id, ok := CorrelationIDFromContext(ctx); ok {
+		child.prefix = "[corr:" + id + "]"
+		if l.prefix != "" {
+			child.prefix += " " + l.prefix
+		}
+	}
+	return child
+}
@@ -0,0 +1,37 @@
+package bolog
+
+import "sync/atomic"
+
+// levelCounts counts the entries written at each level.
+type levelCounts [FatalLevel + 1]atomic.Uint64
+
+// count records an entry written at level, ignoring levels outside the supported range.
+func (c *core) count(level Level) {
+	if level >= DebugLevel && level <= FatalLevel {
+		c.counts.Load()[level].Add(1)
+	}
+}
+
+// CountAt returns the number of entries written at level, across the logger and the loggers derived from it.
+// Entries below the minimum level, dropped by the rate limit or SampleRate, or that failed to be written are not counted.
+func (l *Logger) CountAt(level Level) uint64 {
+	if level < DebugLevel || level > FatalLevel {
+		return 0
+	}
+	return l.core.counts.Load()[level].Load()
+}
+
+// Counts returns the number of entries written at every level, as counted by CountAt.
+func (l *Logger) Counts() map[Level]uint64 {
+	counts := l.core.counts.Load()
+	m := make(map[Level]uint64, len(counts))
+	for level := range counts {
+		m[Level(level)] = counts[level].Load()
+	}
+	return m
+}
+
+// ResetCounts sets every counter to zero at once, so that no entry is counted at some levels and not others.
+func (l *Logger) ResetCounts() {
+	l.core.counts.Store(new(levelCounts))
+}
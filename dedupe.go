@@ -0,0 +1,57 @@
+package bolog
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// deduper tracks the last message written to detect consecutive repeats.
+type deduper struct {
+	mu       sync.Mutex
+	message  string
+	level    Level
+	since    time.Time // When the current run of repeats started
+	repeated int       // Repeats suppressed since
+}
+
+// check reports whether entry repeats the previous message within window and must be suppressed.
+// When a run of repeats ends, because the message changed or the window expired,
+// it also returns a summary entry to write before entry.
+func (d *deduper) check(entry Entry, window time.Duration) (summary *Entry, suppress bool) {
+	now := time.Now()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if entry.Message == d.message && now.Sub(d.since) < window {
+		d.repeated++
+		return nil, true
+	}
+	summary = d.summary()
+	d.message = entry.Message
+	d.level = entry.Level
+	d.since = now
+	d.repeated = 0
+	return summary, false
+}
+
+// flush returns the summary of the pending run of repeats, if any, and ends it.
+func (d *deduper) flush() *Entry {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	summary := d.summary()
+	d.repeated = 0
+	return summary
+}
+
+// summary returns an entry reporting the suppressed repeats, or nil if there were none.
+func (d *deduper) summary() *Entry {
+	if d.repeated == 0 {
+		return nil
+	}
+	return &Entry{
+		Level:   d.level,
+		Message: fmt.Sprintf("[previous message repeated %d times]", d.repeated),
+	}
+}
@@ -0,0 +1,77 @@
+package bolog
+
+import (
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	defaultLogger     atomic.Pointer[Logger]
+	defaultLoggerOnce sync.Once
+)
+
+// SetDefault makes l the logger used by the package-level logging functions.
+// It is safe to call concurrently with them.
+func SetDefault(l *Logger) {
+	defaultLoggerOnce.Do(func() {})
+	defaultLogger.Store(l)
+}
+
+// Default returns the logger used by the package-level logging functions.
+// Until SetDefault is called it writes text entries to standard error.
+func Default() *Logger {
+	defaultLoggerOnce.Do(func() {
+		// Hide Close from NewWriterLogger, so that closing the default logger does not close stderr.
+		defaultLogger.Store(NewWriterLogger(struct{ io.Writer }{os.Stderr}))
+	})
+	return defaultLogger.Load()
+}
+
+// Logf logs a formatted message at InfoLevel with the default logger.
+func Logf(format string, v ...interface{}) {
+	Default().logf(InfoLevel, format, v...)
+}
+
+// Debugf logs a formatted message at DebugLevel with the default logger.
+func Debugf(format string, v ...interface{}) {
+	Default().logf(DebugLevel, format, v...)
+}
+
+// Infof logs a formatted message at InfoLevel with the default logger.
+func Infof(format string, v ...interface{}) {
+	Default().logf(InfoLevel, format, v...)
+}
+
+// Warnf logs a formatted message at WarnLevel with the default logger.
+func Warnf(format string, v ...interface{}) {
+	Default().logf(WarnLevel, format, v...)
+}
+
+// Errorf logs a formatted message at ErrorLevel with the default logger.
+func Errorf(format string, v ...interface{}) {
+	Default().logf(ErrorLevel, format, v...)
+}
+
+// Fatalf logs a formatted message at FatalLevel with the default logger, closes it and then calls os.Exit(1).
+func Fatalf(format string, v ...interface{}) {
+	Default().Fatalf(format, v...)
+}
+
+// LogInfo logs msg at InfoLevel with the default logger.
+func LogInfo(msg string) {
+	Default().LogInfo(msg)
+}
+
+// LogError logs err at ErrorLevel followed by the stack trace of the calling goroutine with the default logger.
+// A nil error is ignored.
+func LogError(err error) {
+	Default().LogError(err)
+}
+
+// LogFatal logs err at FatalLevel followed by the stack trace of the calling goroutine with the default logger,
+// closes it and then calls os.Exit(1).
+func LogFatal(err error) {
+	Default().LogFatal(err)
+}
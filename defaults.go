@@ -0,0 +1,72 @@
+package bolog
+
+// DefaultConfig returns a ConfigLogger populated with sensible defaults,
+// so callers only need to override the fields they care about.
+func DefaultConfig() ConfigLogger {
+	return ConfigLogger{
+		LogDir:           "logs",
+		MaxSize:          100,
+		MaxBackups:       7,
+		MaxAge:           30,
+		Compress:         true,
+		Timezone:         "UTC",
+		Level:            InfoLevel.String(),
+		Format:           "text",
+		FilenameTemplate: defaultFilenameTemplate,
+		RotateBy:         "daily",
+		TimestampFormat:  defaultTimestampFormat,
+		CompressAlgo:     "gzip",
+		DirPerm:          0o755,
+		FilePerm:         0o644,
+		OpenMode:         "append",
+	}
+}
+
+// withDefaults returns a copy of c where every zero-valued field is replaced by its default.
+// Compress is left untouched since false cannot be told apart from unset.
+func (c ConfigLogger) withDefaults() ConfigLogger {
+	defaults := DefaultConfig()
+	if c.LogDir == "" {
+		c.LogDir = defaults.LogDir
+	}
+	if c.MaxSize == 0 {
+		c.MaxSize = defaults.MaxSize
+	}
+	if c.MaxBackups == 0 {
+		c.MaxBackups = defaults.MaxBackups
+	}
+	if c.MaxAge == 0 {
+		c.MaxAge = defaults.MaxAge
+	}
+	if c.Timezone == "" {
+		c.Timezone = defaults.Timezone
+	}
+	if c.Level == "" {
+		c.Level = defaults.Level
+	}
+	if c.Format == "" {
+		c.Format = defaults.Format
+	}
+	if c.FilenameTemplate == "" {
+		c.FilenameTemplate = defaults.FilenameTemplate
+	}
+	if c.RotateBy == "" {
+		c.RotateBy = defaults.RotateBy
+	}
+	if c.TimestampFormat == "" {
+		c.TimestampFormat = defaults.TimestampFormat
+	}
+	if c.CompressAlgo == "" {
+		c.CompressAlgo = defaults.CompressAlgo
+	}
+	if c.DirPerm == 0 {
+		c.DirPerm = defaults.DirPerm
+	}
+	if c.FilePerm == 0 {
+		c.FilePerm = defaults.FilePerm
+	}
+	if c.OpenMode == "" {
+		c.OpenMode = defaults.OpenMode
+	}
+	return c
+}
@@ -0,0 +1,37 @@
+package bolog
+
+import (
+	"strconv"
+	"time"
+)
+
+// Fields written by LogDuration in the formats other than text.
+const (
+	OperationKey  = "operation"
+	DurationMSKey = "duration_ms" // Milliseconds, with microsecond precision
+)
+
+// LogDuration logs at InfoLevel that operation completed after d, e.g. "db_query completed [db_query: 12.5ms]"
+// in the text format and with the OperationKey and DurationMSKey fields in the others.
+func (l *Logger) LogDuration(operation string, d time.Duration) {
+	if !l.enabled(InfoLevel) {
+		return
+	}
+	ms := float64(d.Microseconds()) / 1000
+	entry := Entry{Level: InfoLevel, Message: operation + " completed"}
+	if l.textFormat() {
+		entry.Message += " [" + operation + ": " + strconv.FormatFloat(ms, 'f', -1, 64) + "ms]"
+	} else {
+		entry.Fields = Fields{OperationKey: operation, DurationMSKey: ms}
+	}
+	l.write(entry)
+}
+
+// Elapsed starts timing operation and returns a function that logs its duration with LogDuration,
+// e.g. defer logger.Elapsed("db_query")().
+func (l *Logger) Elapsed(operation string) func() {
+	start := time.Now()
+	return func() {
+		l.LogDuration(operation, time.Since(start))
+	}
+}
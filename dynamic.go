@@ -0,0 +1,42 @@
+package bolog
+
+// fieldProvider returns fields computed when an entry is written, see DynamicFields.
+type fieldProvider func() map[string]interface{}
+
+// DynamicFields registers provider to be called for every entry written by the logger and the loggers
+// derived from it, merging the fields it returns into the entry, e.g. the current goroutine count.
+// Providers are called in the order they were registered, later ones overriding earlier ones;
+// the logger's fields are overridden by the providers' and the entry's own fields override both.
+// provider must be safe for concurrent use and must not log.
+func (l *Logger) DynamicFields(provider func() map[string]interface{}) {
+	l.core.mu.Lock()
+	defer l.core.mu.Unlock()
+	providers := make([]fieldProvider, len(l.core.providers), len(l.core.providers)+1)
+	copy(providers, l.core.providers)
+	l.core.providers = append(providers, provider)
+}
+
+// fieldProviders returns the providers registered with DynamicFields.
+func (l *Logger) fieldProviders() []fieldProvider {
+	l.core.mu.RLock()
+	defer l.core.mu.RUnlock()
+	return l.core.providers
+}
+
+// dynamicFields returns the fields returned by the registered providers, or nil if there are none.
+func (l *Logger) dynamicFields() Fields {
+	var fields Fields
+	for _, provider := range l.fieldProviders() {
+		values := provider()
+		if len(values) == 0 {
+			continue
+		}
+		if fields == nil {
+			fields = make(Fields, len(values))
+		}
+		for key, value := range values {
+			fields[key] = value
+		}
+	}
+	return fields
+}
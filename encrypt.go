@@ -0,0 +1,119 @@
+package bolog
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// EncryptionKeySize is the length in bytes of EncryptionKey, selecting AES-256.
+const EncryptionKeySize = 32
+
+// ErrDecrypt is returned when reading an encrypted log file with the wrong key or a corrupted record.
+var ErrDecrypt = errors.New("bolog: cannot decrypt log record")
+
+// maxRecordSize bounds the length read from a record header, so a corrupted file cannot exhaust memory.
+const maxRecordSize = 1 << 30
+
+// newAEAD returns the AES-256-GCM cipher for key.
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != EncryptionKeySize {
+		return nil, fmt.Errorf("encryption key must be %d bytes, got %d", EncryptionKeySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// sealRecord encrypts p with a random nonce into a record written to an encrypted log file:
+// the 4-byte big-endian length of the rest, the nonce and the ciphertext.
+func sealRecord(aead cipher.AEAD, p []byte) ([]byte, error) {
+	size := recordSize(aead, len(p)) - 4
+	record := make([]byte, 4+aead.NonceSize(), 4+size)
+	binary.BigEndian.PutUint32(record, uint32(size))
+	nonce := record[4:]
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(record, nonce, p, nil), nil
+}
+
+// recordSize returns the size of the record sealRecord makes for n bytes:
+// the length prefix, the nonce, the ciphertext and the tag.
+func recordSize(aead cipher.AEAD, n int) int {
+	return 4 + aead.NonceSize() + n + aead.Overhead()
+}
+
+// NewDecryptingReader opens the log file at path, written with EncryptionKey set to key,
+// and returns a reader of its decrypted contents. Files compressed after encryption,
+// whose name ends in ".gz" or ".zst", are decompressed first.
+// The reader implements io.Closer, closing the file.
+func NewDecryptingReader(path string, key []byte) (io.Reader, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	src, release, err := decompress(path, f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &decryptingReader{aead: aead, src: src, close: func() error {
+		release()
+		return f.Close()
+	}}, nil
+}
+
+// decryptingReader decrypts the records of an encrypted log file one at a time.
+type decryptingReader struct {
+	aead    cipher.AEAD
+	src     io.Reader
+	close   func() error
+	pending []byte // Decrypted bytes not read yet
+}
+
+// Read implements io.Reader.
+func (d *decryptingReader) Read(p []byte) (int, error) {
+	for len(d.pending) == 0 {
+		var header [4]byte
+		if _, err := io.ReadFull(d.src, header[:]); err != nil {
+			return 0, err
+		}
+		size := binary.BigEndian.Uint32(header[:])
+		if size < uint32(d.aead.NonceSize()+d.aead.Overhead()) || size > maxRecordSize {
+			return 0, ErrDecrypt
+		}
+		record := make([]byte, size)
+		if _, err := io.ReadFull(d.src, record); err != nil {
+			if errors.Is(err, io.EOF) {
+				err = io.ErrUnexpectedEOF
+			}
+			return 0, err
+		}
+		nonce, ciphertext := record[:d.aead.NonceSize()], record[d.aead.NonceSize():]
+		plaintext, err := d.aead.Open(ciphertext[:0], nonce, ciphertext, nil)
+		if err != nil {
+			return 0, ErrDecrypt
+		}
+		d.pending = plaintext
+	}
+	n := copy(p, d.pending)
+	d.pending = d.pending[n:]
+	return n, nil
+}
+
+// Close implements io.Closer.
+func (d *decryptingReader) Close() error {
+	return d.close()
+}
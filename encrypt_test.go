@@ -0,0 +1,148 @@
+package bolog_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Lacolle87/bolog"
+)
+
+// testKey returns an encryption key made of b.
+func testKey(b byte) []byte {
+	return bytes.Repeat([]byte{b}, bolog.EncryptionKeySize)
+}
+
+// decryptAll returns the decrypted contents of the log file at path.
+func decryptAll(path string, key []byte) (string, error) {
+	r, err := bolog.NewDecryptingReader(path, key)
+	if err != nil {
+		return "", err
+	}
+	defer r.(io.Closer).Close()
+	data, err := io.ReadAll(r)
+	return string(data), err
+}
+
+func TestEncryptionRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []bolog.Option
+	}{
+		{name: "text"},
+		{name: "json", opts: []bolog.Option{bolog.WithFormat("json")}},
+		{name: "compressed on write", opts: []bolog.Option{bolog.WithCompressOnWrite(true)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := testKey(1)
+			path := writeTestLog(t, append(tt.opts, bolog.WithEncryptionKey(key))...)
+
+			raw, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, e := range testEvents {
+				if bytes.Contains(raw, []byte(e.Message)) {
+					t.Errorf("log file contains %q in plaintext", e.Message)
+				}
+			}
+
+			plain, err := decryptAll(path, key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, e := range testEvents {
+				if !strings.Contains(plain, e.Message) {
+					t.Errorf("decrypted log = %q, want it to contain %q", plain, e.Message)
+				}
+			}
+		})
+	}
+}
+
+func TestEncryptedLogReader(t *testing.T) {
+	key := testKey(2)
+	path := writeTestLog(t, bolog.WithFormat("json"), bolog.WithEncryptionKey(key))
+	r, err := bolog.NewLogReaderWithKey(path, "json", key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries := readAll(t, r)
+	if len(entries) != len(testEvents) {
+		t.Fatalf("read %d entries, want %d", len(entries), len(testEvents))
+	}
+	for i, got := range entries {
+		if want := testEvents[i]; got.Message != want.Message || !got.Time.Equal(want.Timestamp) {
+			t.Errorf("entry %d = %q at %v, want %q at %v", i, got.Message, got.Time, want.Message, want.Timestamp)
+		}
+	}
+}
+
+func TestDecryptionErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		key    []byte
+		tamper func(data []byte) []byte
+		is     error
+	}{
+		{name: "wrong key", key: testKey(4), is: bolog.ErrDecrypt},
+		{
+			name:   "flipped ciphertext byte",
+			tamper: func(data []byte) []byte { data[len(data)/2] ^= 0xff; return data },
+			is:     bolog.ErrDecrypt,
+		},
+		{
+			name:   "truncated record",
+			tamper: func(data []byte) []byte { return data[:len(data)-1] },
+			is:     io.ErrUnexpectedEOF,
+		},
+		{
+			name:   "corrupted length",
+			tamper: func(data []byte) []byte { data[0] = 0xff; return data },
+			is:     bolog.ErrDecrypt,
+		},
+		{name: "short key", key: testKey(3)[:16]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := testKey(3)
+			path := writeTestLog(t, bolog.WithEncryptionKey(key))
+			if tt.tamper != nil {
+				data, err := os.ReadFile(path)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, tt.tamper(data), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tt.key != nil {
+				key = tt.key
+			}
+
+			_, err := decryptAll(path, key)
+			if err == nil {
+				t.Fatal("decryption succeeded, want an error")
+			}
+			if tt.is != nil && !errors.Is(err, tt.is) {
+				t.Errorf("decryption error = %v, want %v", err, tt.is)
+			}
+		})
+	}
+}
+
+func TestInvalidEncryptionKey(t *testing.T) {
+	for _, size := range []int{1, 16, 24, 33} {
+		config := bolog.DefaultConfig()
+		config.LogDir = t.TempDir()
+		config.EncryptionKey = make([]byte, size)
+		if l, err := bolog.SetupLogger(config); err == nil {
+			l.Close()
+			t.Errorf("SetupLogger() with a %d-byte key succeeded, want an error", size)
+		}
+	}
+}
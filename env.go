@@ -0,0 +1,155 @@
+package bolog
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// InitializeLoggerFromEnv reads the logger configuration from environment variables
+// named after prefix (see LoadLoggerConfigFromEnv) and initializes a logger.
+// It returns a pointer to the initialized Logger or an error if a variable cannot be parsed
+// or the log directory cannot be created.
+func InitializeLoggerFromEnv(prefix string) (*Logger, error) {
+	loggerConfig, err := LoadLoggerConfigFromEnv(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return SetupLogger(loggerConfig)
+}
+
+// LoadLoggerConfigFromEnv builds a ConfigLogger from environment variables named after the fields
+// in upper snake case with the given prefix, e.g. PREFIX_LOG_DIR, PREFIX_MAX_SIZE, PREFIX_MAX_BACKUPS,
+// PREFIX_MAX_AGE, PREFIX_COMPRESS or PREFIX_TIMEZONE (see applyEnv for the full list).
+// Unset variables leave the corresponding field zero-valued.
+func LoadLoggerConfigFromEnv(prefix string) (ConfigLogger, error) {
+	var config ConfigLogger
+	if err := applyEnv(&config, prefix); err != nil {
+		return ConfigLogger{}, err
+	}
+	return config, nil
+}
+
+// applyEnv overrides the fields of config for which an environment variable is set.
+func applyEnv(config *ConfigLogger, prefix string) error {
+	env := envLookup{prefix: prefix}
+	env.string("LOG_DIR", &config.LogDir)
+	env.int("MAX_SIZE", &config.MaxSize)
+	env.int("MAX_BACKUPS", &config.MaxBackups)
+	env.int("MAX_AGE", &config.MaxAge)
+	env.int("MAX_LINES", &config.MaxLines)
+	env.bool("COMPRESS", &config.Compress)
+	env.bool("COMPRESS_ON_WRITE", &config.CompressOnWrite)
+	env.string("COMPRESS_ALGO", &config.CompressAlgo)
+	env.bool("CHECKSUM", &config.Checksum)
+	env.bool("ARCHIVE_BY_DATE", &config.ArchiveByDate)
+	env.bool("LOCAL_BACKUP_TIME", &config.LocalBackupTime)
+	env.string("OPEN_MODE", &config.OpenMode)
+	env.string("TIMEZONE", &config.Timezone)
+	env.string("LEVEL", &config.Level)
+	env.string("FORMAT", &config.Format)
+	env.int("CALLER_DEPTH", &config.CallerDepth)
+	env.string("ROTATE_INTERVAL", &config.RotateInterval)
+	env.string("ROTATE_BY", &config.RotateBy)
+	env.string("FILENAME_TEMPLATE", &config.FilenameTemplate)
+	env.string("TIMESTAMP_FORMAT", &config.TimestampFormat)
+	env.bool("SEQUENCE_NUMBERS", &config.SequenceNumbers)
+	env.bool("INCLUDE_HOSTNAME", &config.IncludeHostname)
+	env.bool("INCLUDE_PID", &config.IncludePID)
+	env.bool("INCLUDE_ELAPSED", &config.IncludeElapsed)
+	env.int("BUFFER_SIZE", &config.BufferSize)
+	env.int("MAX_TOTAL_MB", &config.MaxTotalMB)
+	env.string("CURRENT_SYMLINK", &config.CurrentSymlink)
+	env.int("MAX_MESSAGE_BYTES", &config.MaxMessageBytes)
+	env.int("WRITE_RETRIES", &config.WriteRetries)
+	env.duration("WRITE_RETRY_DELAY", &config.WriteRetryDelay)
+	env.duration("WRITE_TIMEOUT", &config.WriteTimeout)
+	env.duration("DEDUPE_WINDOW", &config.DedupeWindow)
+	env.int("SAMPLE_RATE", &config.SampleRate)
+	env.bool("EXCLUSIVE_LOCK", &config.ExclusiveLock)
+	env.duration("LOCK_TIMEOUT", &config.LockTimeout)
+	env.perm("DIR_PERM", &config.DirPerm)
+	env.perm("FILE_PERM", &config.FilePerm)
+	env.string("SYSLOG_NETWORK", &config.Syslog.Network)
+	env.string("SYSLOG_ADDR", &config.Syslog.Addr)
+	env.string("SYSLOG_PRIORITY", &config.Syslog.Priority)
+	env.string("SYSLOG_TAG", &config.Syslog.Tag)
+	return env.err
+}
+
+// envLookup reads prefixed environment variables, remembering the first parse failure.
+type envLookup struct {
+	prefix string
+	err    error
+}
+
+// lookup returns the full variable name for key and its value, if set.
+func (e *envLookup) lookup(key string) (string, string, bool) {
+	name := key
+	if e.prefix != "" {
+		name = e.prefix + "_" + key
+	}
+	value, ok := os.LookupEnv(name)
+	return name, value, ok && e.err == nil
+}
+
+func (e *envLookup) string(key string, dst *string) {
+	if _, value, ok := e.lookup(key); ok {
+		*dst = value
+	}
+}
+
+func (e *envLookup) int(key string, dst *int) {
+	name, value, ok := e.lookup(key)
+	if !ok {
+		return
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		e.err = fmt.Errorf("invalid value %q for %s: %w", value, name, err)
+		return
+	}
+	*dst = n
+}
+
+func (e *envLookup) duration(key string, dst *time.Duration) {
+	name, value, ok := e.lookup(key)
+	if !ok {
+		return
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		e.err = fmt.Errorf("invalid value %q for %s: %w", value, name, err)
+		return
+	}
+	*dst = d
+}
+
+// perm parses an octal file mode such as "0700".
+func (e *envLookup) perm(key string, dst *os.FileMode) {
+	name, value, ok := e.lookup(key)
+	if !ok {
+		return
+	}
+	mode, err := strconv.ParseUint(value, 8, 32)
+	if err != nil {
+		e.err = fmt.Errorf("invalid value %q for %s: %w", value, name, err)
+		return
+	}
+	*dst = os.FileMode(mode)
+}
+
+func (e *envLookup) bool(key string, dst *bool) {
+	name, value, ok := e.lookup(key)
+	if !ok {
+		return
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		e.err = fmt.Errorf("invalid value %q for %s: %w", value, name, err)
+		return
+	}
+	*dst = b
+}
@@ -0,0 +1,82 @@
+package bolog
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Fields added by LogErrorf besides ErrorKey.
+const (
+	ErrorChainKey = "error_chain" // Messages of the errors wrapped by the logged error, breadth-first, outermost first
+	ErrorCodeKey  = "error_code"  // Code of the first error in the chain with a Code() string method
+)
+
+// LogErrorf logs a formatted message at ErrorLevel with err as the ErrorKey field,
+// written as "error=..." by the text format and as an "error" key by JSON.
+// If err wraps other errors, including several at once as with errors.Join,
+// their messages up to maxErrorChainDepth levels deep are added as ErrorChainKey, and if an error
+// in the chain has a Code() string method its code is added as ErrorCodeKey. A nil err is logged as the message alone.
+func (l *Logger) LogErrorf(err error, format string, v ...interface{}) {
+	if !l.enabled(ErrorLevel) {
+		return
+	}
+	l.write(Entry{Level: ErrorLevel, Message: fmt.Sprintf(format, v...), Fields: errorFields(err)})
+}
+
+// errorFields returns the fields describing err for LogErrorf, nil if err is nil.
+func errorFields(err error) Fields {
+	if err == nil {
+		return nil
+	}
+	fields := Fields{ErrorKey: err.Error()}
+	if chain := wrappedErrors(err); len(chain) > 0 {
+		fields[ErrorChainKey] = chain
+	}
+	var coder interface{ Code() string }
+	if errors.As(err, &coder) {
+		fields[ErrorCodeKey] = coder.Code()
+	}
+	return fields
+}
+
+// maxErrorChainDepth bounds how many levels of wrapped errors LogErrorf follows.
+const maxErrorChainDepth = 16
+
+// wrappedErrors returns the messages of the errors err wraps, with Unwrap() error or Unwrap() []error,
+// breadth-first and at most maxErrorChainDepth levels deep.
+func wrappedErrors(err error) errorChain {
+	var chain errorChain
+	level := []error{err}
+	for depth := 0; depth < maxErrorChainDepth && len(level) > 0; depth++ {
+		var next []error
+		for _, e := range level {
+			switch e := e.(type) {
+			case interface{ Unwrap() error }:
+				if wrapped := e.Unwrap(); wrapped != nil {
+					next = append(next, wrapped)
+				}
+			case interface{ Unwrap() []error }:
+				for _, wrapped := range e.Unwrap() {
+					if wrapped != nil {
+						next = append(next, wrapped)
+					}
+				}
+			}
+		}
+		for _, wrapped := range next {
+			chain = append(chain, wrapped.Error())
+		}
+		level = next
+	}
+	return chain
+}
+
+// errorChain holds the messages of wrapped errors. It is written as a JSON array,
+// and as the messages separated by " | " by the text format.
+type errorChain []string
+
+// String implements fmt.Stringer.
+func (c errorChain) String() string {
+	return strings.Join(c, " | ")
+}
@@ -0,0 +1,50 @@
+package bolog
+
+import (
+	"log"
+	"time"
+)
+
+// rotationEventBuffer is the capacity of the channel returned by RotationEvents.
+const rotationEventBuffer = 16
+
+// RotationEvent describes a rotation, manual or automatic, of the log file.
+type RotationEvent struct {
+	OldFile string    // Path of the completed file, after it was moved aside and compressed if it was
+	NewFile string    // Path of the file written from now on
+	Time    time.Time // When the rotation happened
+}
+
+// RotationEvents returns a channel receiving an event for every rotation of the logger's file.
+// The channel is buffered and events are dropped when it is full, so logging never blocks on it.
+// It is shared by the loggers derived from l and never closed.
+func (l *Logger) RotationEvents() <-chan RotationEvent {
+	l.core.mu.Lock()
+	defer l.core.mu.Unlock()
+	if l.core.events == nil {
+		l.core.events = make(chan RotationEvent, rotationEventBuffer)
+	}
+	return l.core.events
+}
+
+// rotated writes the checksum of the completed file if Checksum is set,
+// then notifies the PostRotateHooks and RotationEvents of event.
+func (c *core) rotated(event RotationEvent) {
+	if config := c.file.settings(); config.Checksum {
+		if err := writeChecksum(event.OldFile, config.FilePerm); err != nil {
+			log.Printf("Error writing log checksum: %v", err)
+		}
+	}
+	c.postRotate(event.OldFile)
+
+	c.mu.RLock()
+	events := c.events
+	c.mu.RUnlock()
+	if events == nil {
+		return
+	}
+	select {
+	case events <- event:
+	default:
+	}
+}
@@ -0,0 +1,29 @@
+package bolog
+
+// SetFields replaces the fields the logger adds to every entry with a copy of fields,
+// e.g. to add a deployment ID known only after construction. It is safe to call while other
+// goroutines are logging. Loggers derived from l before the call keep their own fields.
+func (l *Logger) SetFields(fields map[string]interface{}) {
+	replaced := make(Fields, len(fields))
+	for key, value := range fields {
+		replaced[key] = value
+	}
+	l.fieldsMu.Lock()
+	defer l.fieldsMu.Unlock()
+	l.fields = replaced
+}
+
+// AddField adds the key-value pair to the fields the logger adds to every entry,
+// replacing the value of an existing key. Like SetFields it is safe for concurrent use.
+func (l *Logger) AddField(key string, value interface{}) {
+	l.fieldsMu.Lock()
+	defer l.fieldsMu.Unlock()
+	l.fields = mergeFields(l.fields, Fields{key: value})
+}
+
+// persistentFields returns the fields the logger adds to every entry. The returned map must not be modified.
+func (l *Logger) persistentFields() Fields {
+	l.fieldsMu.RLock()
+	defer l.fieldsMu.RUnlock()
+	return l.fields
+}
@@ -0,0 +1,129 @@
+package bolog
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// LogFileInfo describes a log file managed by the logger.
+type LogFileInfo struct {
+	Path       string
+	Size       int64
+	ModTime    time.Time
+	Compressed bool // Whether the file is a gzip or zstd compressed backup
+}
+
+// ListLogFiles returns the current log file and the backups made by rotation found in LogDir,
+// including those archived by ArchiveByDate, newest first. Files are matched against FilenameTemplate, where every digit of the layout
+// matches any digit and month and weekday names match any name, so files from earlier days are included.
+func (l *Logger) ListLogFiles() ([]LogFileInfo, error) {
+	return listLogFiles(l.core.file.settings())
+}
+
+// DiskUsageMB returns the total size in megabytes of the files listed by ListLogFiles.
+// Compressed backups count with their compressed size.
+func (l *Logger) DiskUsageMB() (float64, error) {
+	files, err := l.ListLogFiles()
+	if err != nil {
+		return 0, err
+	}
+	return float64(totalSize(files)) / megabyte, nil
+}
+
+// WriteTo flushes buffered entries and copies the current log file, as stored on disk, to w.
+// It implements io.WriterTo and works while entries are being logged: the file is opened
+// between writes, and if it is rotated while being copied the copy ends with the completed file.
+// A file that has not been created yet copies nothing. It returns ErrNoLogFile for loggers created with NewWriterLogger.
+func (l *Logger) WriteTo(w io.Writer) (int64, error) {
+	if l.core.fileless {
+		return 0, ErrNoLogFile
+	}
+	f, err := l.core.file.open()
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	return io.Copy(w, f)
+}
+
+// listLogFiles returns the log files in config.LogDir, newest first.
+func listLogFiles(config ConfigLogger) ([]LogFileInfo, error) {
+	pattern := logFilePattern(getLogFileName(config, time.Now().In(getTimezone(config.Timezone))))
+
+	var files []LogFileInfo
+	err := filepath.WalkDir(config.LogDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(config.LogDir, path)
+		if err != nil || !pattern.MatchString(filepath.ToSlash(rel)) {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		files = append(files, LogFileInfo{
+			Path:       path,
+			Size:       info.Size(),
+			ModTime:    info.ModTime(),
+			Compressed: isCompressed(path),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(files, func(i, j int) bool {
+		return files[i].ModTime.After(files[j].ModTime)
+	})
+	return files, nil
+}
+
+// totalSize returns the sum of the sizes of files.
+func totalSize(files []LogFileInfo) int64 {
+	var total int64
+	for _, f := range files {
+		total += f.Size
+	}
+	return total
+}
+
+// nameRE matches the English month and weekday names time.Format can produce.
+var nameRE = regexp.MustCompile(`January|February|March|April|May|June|July|August|September|October|November|December|` +
+	`Jan|Feb|Mar|Apr|Jun|Jul|Aug|Sep|Oct|Nov|Dec|` +
+	`Monday|Tuesday|Wednesday|Thursday|Friday|Saturday|Sunday|Mon|Tue|Wed|Thu|Fri|Sat|Sun`)
+
+// logFilePattern returns a regexp matching name, a file name produced from the filename template,
+// with any date and time, and the names lumberjack gives its backups, optionally compressed.
+func logFilePattern(name string) *regexp.Regexp {
+	generalize := func(s string) string {
+		var b strings.Builder
+		last := 0
+		for _, loc := range nameRE.FindAllStringIndex(s, -1) {
+			b.WriteString(regexp.QuoteMeta(s[last:loc[0]]))
+			b.WriteString(`[A-Za-z]+`)
+			last = loc[1]
+		}
+		b.WriteString(regexp.QuoteMeta(s[last:]))
+		return strings.NewReplacer("0", `\d`, "1", `\d`, "2", `\d`, "3", `\d`, "4", `\d`,
+			"5", `\d`, "6", `\d`, "7", `\d`, "8", `\d`, "9", `\d`).Replace(b.String())
+	}
+	name = filepath.ToSlash(name)
+	ext := filepath.Ext(name)
+	stem := strings.TrimSuffix(name, ext)
+	// lumberjack inserts the rotation time between the name and the extension.
+	backup := `(-\d{4}-\d{2}-\d{2}T\d{2}-\d{2}-\d{2}\.\d{3})?`
+	// ArchiveByDate moves files into dated subdirectories.
+	archived := `(\d{4}/\d{2}/\d{2}/)?`
+	return regexp.MustCompile("^" + archived + generalize(stem) + backup + generalize(ext) + `(\.gz|\.zst)?$`)
+}
@@ -0,0 +1,414 @@
+package bolog
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultTimestampFormat is the layout used for entry timestamps when none is configured.
+const defaultTimestampFormat = "2006-01-02 15:04:05"
+
+// TimestampUnixMilli is a TimestampFormat value that writes timestamps as Unix epoch milliseconds.
+const TimestampUnixMilli = "unixms"
+
+// Fields holds structured key-value pairs attached to a log entry.
+type Fields map[string]interface{}
+
+// Entry is a single log record passed to a Formatter.
+type Entry struct {
+	Time    time.Time
+	Level   Level
+	Message string
+	Fields  Fields
+	Caller  string // "file.go:123" of the logging call, empty unless CallerDepth is set
+	Stack   string // Stack trace captured by LogError and LogFatal
+	Seq     uint64 // Position of the entry in the current file, 0 unless SequenceNumbers is set
+
+	Hostname string        // Host name, empty unless IncludeHostname is set
+	PID      int           // Process ID, 0 unless IncludePID is set
+	Elapsed  time.Duration // Time since the logger was created, 0 unless IncludeElapsed is set
+
+	Context context.Context // Context passed to LogfCtx or ForRequest, nil otherwise; never formatted
+}
+
+// Formatter turns an Entry into the bytes written to the log file, including the trailing newline.
+type Formatter interface {
+	Format(entry Entry) ([]byte, error)
+}
+
+// HeaderFormatter is a Formatter whose files start with a header, such as the column names of CSV.
+// The header is written whenever a new log file is started.
+type HeaderFormatter interface {
+	Formatter
+	Header() []byte
+}
+
+// TextFormatter writes entries as "[timestamp] [LEVEL] -- message key=value",
+// preceded by the sequence number as "[0000042]" when set, followed by the elapsed time,
+// host name and process ID as "[+0h01m02.345s] [host] [pid:123]" when set, and with the caller
+// inserted as "[file.go:123]" before "--" when known.
+// The TraceIDKey and SpanIDKey fields follow the message as "[trace:X span:Y]" rather than as key=value.
+// Nested Fields values are flattened into dotted keys such as "group.key=value".
+// A stack trace follows on subsequent lines, each indented with a tab.
+type TextFormatter struct {
+	TimestampFormat string // Layout passed to time.Time.Format or TimestampUnixMilli, defaults to "2006-01-02 15:04:05"
+}
+
+// Format implements Formatter.
+func (f TextFormatter) Format(entry Entry) ([]byte, error) {
+	var buf bytes.Buffer
+	if entry.Seq > 0 {
+		fmt.Fprintf(&buf, "[%07d] ", entry.Seq)
+	}
+	buf.WriteByte('[')
+	buf.WriteString(formatTimestamp(entry.Time, f.TimestampFormat))
+	if entry.Elapsed != 0 {
+		buf.WriteString("] [")
+		buf.WriteString(formatElapsed(entry.Elapsed))
+	}
+	if entry.Hostname != "" {
+		buf.WriteString("] [")
+		buf.WriteString(entry.Hostname)
+	}
+	if entry.PID != 0 {
+		buf.WriteString("] [pid:")
+		buf.WriteString(strconv.Itoa(entry.PID))
+	}
+	buf.WriteString("] [")
+	buf.WriteString(entry.Level.String())
+	if entry.Caller != "" {
+		buf.WriteString("] [")
+		buf.WriteString(entry.Caller)
+	}
+	buf.WriteString("] -- ")
+	buf.WriteString(entry.Message)
+	writeTextFields(&buf, "", writeTextTrace(&buf, entry.Fields))
+	buf.WriteByte('\n')
+	for _, line := range strings.Split(strings.TrimRight(entry.Stack, "\n"), "\n") {
+		if line == "" {
+			continue
+		}
+		buf.WriteByte('\t')
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+// JSONFormatter writes entries as single-line JSON objects with "time", "level", "message" and,
+// when set, "seq", "elapsed_ms", "hostname", "pid", "caller" and "stack" keys followed by the entry fields.
+type JSONFormatter struct {
+	TimestampFormat string // Layout passed to time.Time.Format or TimestampUnixMilli, defaults to "2006-01-02 15:04:05"
+}
+
+// Format implements Formatter.
+func (f JSONFormatter) Format(entry Entry) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	if entry.Seq > 0 {
+		writeJSONPair(&buf, "seq", entry.Seq)
+		buf.WriteByte(',')
+	}
+	if f.TimestampFormat == TimestampUnixMilli {
+		writeJSONPair(&buf, "time", entry.Time.UnixMilli())
+	} else {
+		writeJSONPair(&buf, "time", formatTimestamp(entry.Time, f.TimestampFormat))
+	}
+	buf.WriteByte(',')
+	writeJSONPair(&buf, "level", entry.Level.String())
+	buf.WriteByte(',')
+	writeJSONPair(&buf, "message", entry.Message)
+	if entry.Elapsed != 0 {
+		buf.WriteByte(',')
+		writeJSONPair(&buf, "elapsed_ms", entry.Elapsed.Milliseconds())
+	}
+	if entry.Hostname != "" {
+		buf.WriteByte(',')
+		writeJSONPair(&buf, "hostname", entry.Hostname)
+	}
+	if entry.PID != 0 {
+		buf.WriteByte(',')
+		writeJSONPair(&buf, "pid", entry.PID)
+	}
+	if entry.Caller != "" {
+		buf.WriteByte(',')
+		writeJSONPair(&buf, "caller", entry.Caller)
+	}
+	if entry.Stack != "" {
+		buf.WriteByte(',')
+		writeJSONPair(&buf, "stack", entry.Stack)
+	}
+	for _, key := range sortedKeys(entry.Fields) {
+		name := key
+		if isReservedJSONKey(name) {
+			// Keep user fields from clobbering the entry's own keys.
+			name = "fields." + name
+		}
+		buf.WriteByte(',')
+		writeJSONPair(&buf, name, entry.Fields[key])
+	}
+	buf.WriteString("}\n")
+	return buf.Bytes(), nil
+}
+
+// NDJSONFormatter writes entries as single-line JSON objects with the short keys expected by
+// log aggregators such as Loki and Fluentd: "ts" in RFC 3339 with nanoseconds, "level" in lower case
+// and "msg", followed when set by "seq", "elapsed_ms", "hostname", "pid", "caller" and "stack" and the entry fields.
+type NDJSONFormatter struct{}
+
+// Format implements Formatter.
+func (NDJSONFormatter) Format(entry Entry) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	writeJSONPair(&buf, "ts", entry.Time.Format(time.RFC3339Nano))
+	buf.WriteByte(',')
+	writeJSONPair(&buf, "level", strings.ToLower(entry.Level.String()))
+	buf.WriteByte(',')
+	writeJSONPair(&buf, "msg", entry.Message)
+	if entry.Seq > 0 {
+		buf.WriteByte(',')
+		writeJSONPair(&buf, "seq", entry.Seq)
+	}
+	if entry.Elapsed != 0 {
+		buf.WriteByte(',')
+		writeJSONPair(&buf, "elapsed_ms", entry.Elapsed.Milliseconds())
+	}
+	if entry.Hostname != "" {
+		buf.WriteByte(',')
+		writeJSONPair(&buf, "hostname", entry.Hostname)
+	}
+	if entry.PID != 0 {
+		buf.WriteByte(',')
+		writeJSONPair(&buf, "pid", entry.PID)
+	}
+	if entry.Caller != "" {
+		buf.WriteByte(',')
+		writeJSONPair(&buf, "caller", entry.Caller)
+	}
+	if entry.Stack != "" {
+		buf.WriteByte(',')
+		writeJSONPair(&buf, "stack", entry.Stack)
+	}
+	for _, key := range sortedKeys(entry.Fields) {
+		name := key
+		if isReservedJSONKey(name) || name == "ts" || name == "msg" {
+			name = "fields." + name
+		}
+		buf.WriteByte(',')
+		writeJSONPair(&buf, name, entry.Fields[key])
+	}
+	buf.WriteString("}\n")
+	return buf.Bytes(), nil
+}
+
+// CSVFormatter writes entries as RFC 4180 rows with the columns timestamp, level, file, line and message,
+// where file and line come from the caller, if known, and the fields follow the message as in TextFormatter.
+// Stack traces are not written.
+type CSVFormatter struct {
+	TimestampFormat string // Layout passed to time.Time.Format or TimestampUnixMilli, defaults to "2006-01-02 15:04:05"
+}
+
+// Format implements Formatter.
+func (f CSVFormatter) Format(entry Entry) ([]byte, error) {
+	var file, line string
+	if entry.Caller != "" {
+		file = entry.Caller
+		if i := strings.LastIndexByte(entry.Caller, ':'); i >= 0 {
+			file, line = entry.Caller[:i], entry.Caller[i+1:]
+		}
+	}
+	var message bytes.Buffer
+	message.WriteString(entry.Message)
+	writeTextFields(&message, "", entry.Fields)
+	return csvRow(formatTimestamp(entry.Time, f.TimestampFormat), entry.Level.String(), file, line, message.String())
+}
+
+// Header implements HeaderFormatter.
+func (f CSVFormatter) Header() []byte {
+	header, _ := csvRow("timestamp", "level", "file", "line", "message")
+	return header
+}
+
+// csvRow renders columns as a single CSV record terminated by a newline.
+func csvRow(columns ...string) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(columns); err != nil {
+		return nil, err
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+// isReservedJSONKey reports whether key is written by JSONFormatter itself.
+func isReservedJSONKey(key string) bool {
+	switch key {
+	case "seq", "time", "level", "message", "elapsed_ms", "hostname", "pid", "caller", "stack":
+		return true
+	}
+	return false
+}
+
+// getFormatter returns the Formatter for the format name and timestamp layout in config,
+// defaulting to TextFormatter if the name is empty or unknown.
+func getFormatter(config ConfigLogger) Formatter {
+	formatter, ok := lookupFormatter(config.Format, config.TimestampFormat)
+	if !ok {
+		return TextFormatter{TimestampFormat: config.TimestampFormat}
+	}
+	return formatter
+}
+
+// lookupFormatter returns the Formatter for the specified format name using timestampFormat,
+// and whether the name is known. An empty name selects the text format.
+func lookupFormatter(format, timestampFormat string) (Formatter, bool) {
+	switch strings.ToLower(format) {
+	case "", "text":
+		return TextFormatter{TimestampFormat: timestampFormat}, true
+	case "json":
+		return JSONFormatter{TimestampFormat: timestampFormat}, true
+	case "csv":
+		return CSVFormatter{TimestampFormat: timestampFormat}, true
+	case "ndjson":
+		return NDJSONFormatter{}, true
+	case "gelf":
+		return GELFFormatter{}, true
+	case "logfmt":
+		return LogfmtFormatter{}, true
+	default:
+		return nil, false
+	}
+}
+
+// formatTimestamp renders t with layout, which may be TimestampUnixMilli,
+// or with the default timestamp layout if it is empty.
+func formatTimestamp(t time.Time, layout string) string {
+	switch layout {
+	case "":
+		return t.Format(defaultTimestampFormat)
+	case TimestampUnixMilli:
+		return strconv.FormatInt(t.UnixMilli(), 10)
+	default:
+		return t.Format(layout)
+	}
+}
+
+// formatElapsed renders d as "+0h01m02.345s".
+func formatElapsed(d time.Duration) string {
+	ms := d.Milliseconds()
+	return fmt.Sprintf("+%dh%02dm%02d.%03ds", ms/3600000, ms/60000%60, ms/1000%60, ms%1000)
+}
+
+// mergeFields returns a new Fields holding base overlaid with extra.
+func mergeFields(base, extra Fields) Fields {
+	merged := make(Fields, len(base)+len(extra))
+	for key, value := range base {
+		merged[key] = value
+	}
+	for key, value := range extra {
+		merged[key] = value
+	}
+	return merged
+}
+
+// sortedKeys returns the keys of fields in lexical order so output is stable.
+func sortedKeys(fields Fields) []string {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// writeTextTrace appends " [trace:X span:Y]" to buf for the trace fields that are set
+// and returns the remaining fields.
+func writeTextTrace(buf *bytes.Buffer, fields Fields) Fields {
+	traceID, hasTrace := fields[TraceIDKey]
+	spanID, hasSpan := fields[SpanIDKey]
+	if !hasTrace && !hasSpan {
+		return fields
+	}
+	buf.WriteString(" [")
+	if hasTrace {
+		buf.WriteString("trace:")
+		buf.WriteString(formatTextValue(traceID))
+	}
+	if hasSpan {
+		if hasTrace {
+			buf.WriteByte(' ')
+		}
+		buf.WriteString("span:")
+		buf.WriteString(formatTextValue(spanID))
+	}
+	buf.WriteByte(']')
+
+	rest := make(Fields, len(fields))
+	for key, value := range fields {
+		if key != TraceIDKey && key != SpanIDKey {
+			rest[key] = value
+		}
+	}
+	return rest
+}
+
+// writeTextFields appends " key=value" for every field to buf in key order,
+// flattening nested Fields under prefix.
+func writeTextFields(buf *bytes.Buffer, prefix string, fields Fields) {
+	for _, key := range sortedKeys(fields) {
+		value := fields[key]
+		if nested, ok := value.(map[string]interface{}); ok {
+			value = Fields(nested)
+		}
+		if nested, ok := value.(Fields); ok {
+			writeTextFields(buf, prefix+key+".", nested)
+			continue
+		}
+		buf.WriteByte(' ')
+		buf.WriteString(prefix)
+		buf.WriteString(key)
+		buf.WriteByte('=')
+		buf.WriteString(formatTextValue(value))
+	}
+}
+
+// formatTextValue renders a field value for the text format,
+// quoting it when it would otherwise be ambiguous.
+func formatTextValue(value interface{}) string {
+	var s string
+	switch v := value.(type) {
+	case string:
+		s = v
+	case error:
+		s = v.Error()
+	default:
+		s = fmt.Sprint(v)
+	}
+	if s == "" || strings.ContainsAny(s, " =\"\t\r\n") {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// writeJSONPair appends a "key":value pair to buf. Values that cannot be
+// marshalled are written as their fmt representation instead.
+func writeJSONPair(buf *bytes.Buffer, key string, value interface{}) {
+	if err, ok := value.(error); ok {
+		value = err.Error()
+	}
+	encodedKey, _ := json.Marshal(key)
+	buf.Write(encodedKey)
+	buf.WriteByte(':')
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		encoded, _ = json.Marshal(fmt.Sprint(value))
+	}
+	buf.Write(encoded)
+}
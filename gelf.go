@@ -0,0 +1,113 @@
+package bolog
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"sync"
+)
+
+// GELFFormatter writes entries as GELF 1.1 JSON objects, one per line, for Graylog and Logstash:
+// "version", "host", "short_message", "timestamp" as Unix seconds and "level" as the syslog severity,
+// followed by the stack trace as "full_message" and the other entry data and fields as additional fields
+// prefixed with "_". Characters not allowed in GELF field names are replaced by "_", and the field "id",
+// which GELF reserves, is written as "_fields.id".
+type GELFFormatter struct {
+	Host string // Value of "host", defaults to the entry's Hostname or else the local host name
+}
+
+// Format implements Formatter.
+func (f GELFFormatter) Format(entry Entry) ([]byte, error) {
+	host := f.Host
+	if host == "" {
+		host = entry.Hostname
+	}
+	if host == "" {
+		host = localHostname()
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	writeJSONPair(&buf, "version", "1.1")
+	buf.WriteByte(',')
+	writeJSONPair(&buf, "host", host)
+	buf.WriteByte(',')
+	writeJSONPair(&buf, "short_message", entry.Message)
+	buf.WriteByte(',')
+	writeJSONPair(&buf, "timestamp", float64(entry.Time.UnixMicro())/1e6)
+	buf.WriteByte(',')
+	writeJSONPair(&buf, "level", gelfLevel(entry.Level))
+	if entry.Stack != "" {
+		buf.WriteByte(',')
+		writeJSONPair(&buf, "full_message", entry.Stack)
+	}
+	if entry.Seq > 0 {
+		buf.WriteByte(',')
+		writeJSONPair(&buf, "_seq", entry.Seq)
+	}
+	if entry.Elapsed != 0 {
+		buf.WriteByte(',')
+		writeJSONPair(&buf, "_elapsed_ms", entry.Elapsed.Milliseconds())
+	}
+	if entry.PID != 0 {
+		buf.WriteByte(',')
+		writeJSONPair(&buf, "_pid", entry.PID)
+	}
+	if entry.Caller != "" {
+		buf.WriteByte(',')
+		writeJSONPair(&buf, "_caller", entry.Caller)
+	}
+	for _, key := range sortedKeys(entry.Fields) {
+		name := gelfFieldName(key)
+		if name == "id" || isReservedGELFKey(name) {
+			name = "fields." + name
+		}
+		buf.WriteByte(',')
+		writeJSONPair(&buf, "_"+name, entry.Fields[key])
+	}
+	buf.WriteString("}\n")
+	return buf.Bytes(), nil
+}
+
+// gelfLevel returns the syslog severity of level.
+func gelfLevel(level Level) int {
+	switch {
+	case level <= DebugLevel:
+		return 7
+	case level == InfoLevel:
+		return 6
+	case level == WarnLevel:
+		return 4
+	case level == ErrorLevel:
+		return 3
+	default:
+		return 2
+	}
+}
+
+// gelfFieldName replaces the characters of key that GELF does not allow in field names with "_".
+func gelfFieldName(key string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '.' || r == '-' {
+			return r
+		}
+		return '_'
+	}, key)
+}
+
+// isReservedGELFKey reports whether the additional field "_"+key is written by GELFFormatter itself.
+func isReservedGELFKey(key string) bool {
+	switch key {
+	case "seq", "elapsed_ms", "pid", "caller":
+		return true
+	}
+	return false
+}
+
+// localHostname returns the host name of the machine, looked up once, or "localhost" if it is unknown.
+var localHostname = sync.OnceValue(func() string {
+	if hostname, err := os.Hostname(); err == nil {
+		return hostname
+	}
+	return "localhost"
+})
@@ -0,0 +1,16 @@
+package bolog
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// DefaultFileHeader is a FileHeader generator writing a comment line with the time the file was started,
+// the host name and the process ID, e.g. "# bolog log started 2024-01-02T15:04:05Z host=web1 pid=123".
+// LogReader and Stats skip lines starting with "#".
+func DefaultFileHeader(config ConfigLogger) string {
+	hostname, _ := os.Hostname()
+	started := time.Now().In(getTimezone(config.Timezone)).Format(time.RFC3339)
+	return fmt.Sprintf("# bolog log started %s host=%s pid=%d", started, hostname, os.Getpid())
+}
@@ -0,0 +1,92 @@
+package bolog
+
+// Hook is notified of every entry written by a logger, before it reaches the log file.
+// Hooks run synchronously on the logging goroutine, so they should be fast.
+type Hook interface {
+	Fire(entry Entry) error
+}
+
+// WrittenHook is a Hook that is also told how many bytes an entry occupied once it was written.
+type WrittenHook interface {
+	Hook
+	Written(entry Entry, n int)
+}
+
+// TransformHook is a Hook that may also rewrite entries, e.g. to add fields.
+// Transform is called for every entry before any hook is fired.
+type TransformHook interface {
+	Hook
+	Transform(entry Entry) Entry
+}
+
+// PreRotateHook is a Hook that is also told about every rotation, manual or automatic,
+// before the current file is completed. It runs on the goroutine that caused the rotation
+// while the log file is locked, so it must not write to the logger.
+type PreRotateHook interface {
+	Hook
+	PreRotate(file string)
+}
+
+// PostRotateHook is a Hook that is also told about every rotation, manual or automatic,
+// with the path the completed file was moved to. It runs on the goroutine that caused the rotation,
+// unless Compress is set and the file is a backup: then it runs once the backup is compressed,
+// on a background goroutine, and is passed the path of the compressed file.
+type PostRotateHook interface {
+	Hook
+	PostRotate(file string)
+}
+
+// AddHook registers h on the logger and the loggers derived from it.
+func (l *Logger) AddHook(h Hook) {
+	l.core.mu.Lock()
+	defer l.core.mu.Unlock()
+	hooks := make([]Hook, len(l.core.hooks), len(l.core.hooks)+1)
+	copy(hooks, l.core.hooks)
+	l.core.hooks = append(hooks, h)
+}
+
+// RemoveHook unregisters a hook previously added with AddHook.
+// Hooks are compared with ==, so h must be comparable, e.g. a pointer.
+func (l *Logger) RemoveHook(h Hook) {
+	l.core.mu.Lock()
+	defer l.core.mu.Unlock()
+	for i, hook := range l.core.hooks {
+		if hook == h {
+			hooks := make([]Hook, 0, len(l.core.hooks)-1)
+			hooks = append(hooks, l.core.hooks[:i]...)
+			l.core.hooks = append(hooks, l.core.hooks[i+1:]...)
+			return
+		}
+	}
+}
+
+// hooks returns the hooks currently registered.
+func (l *Logger) hooks() []Hook {
+	l.core.mu.RLock()
+	defer l.core.mu.RUnlock()
+	return l.core.hooks
+}
+
+// preRotate calls PreRotate on every registered PreRotateHook.
+func (c *core) preRotate(file string) {
+	c.mu.RLock()
+	hooks := c.hooks
+	c.mu.RUnlock()
+	for _, hook := range hooks {
+		if rotate, ok := hook.(PreRotateHook); ok {
+			rotate.PreRotate(file)
+		}
+	}
+}
+
+// postRotate calls PostRotate on every registered PostRotateHook.
+func (c *core) postRotate(file string) {
+	c.mu.RLock()
+	hooks := c.hooks
+	c.mu.RUnlock()
+	for _, hook := range hooks {
+		if rotate, ok := hook.(PostRotateHook); ok {
+			rotate.PostRotate(file)
+		}
+	}
+}
@@ -0,0 +1,271 @@
+// Package httpbackend ships bolog entries to a remote HTTP endpoint in batches.
+package httpbackend
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Lacolle87/bolog"
+)
+
+// ErrShutdown is returned when writing to or shutting down an HTTPBackend after Shutdown.
+var ErrShutdown = errors.New("httpbackend: backend is shut down")
+
+const (
+	defaultFlushInterval = 5 * time.Second
+	defaultMaxBatchSize  = 100
+	maxRetries           = 5
+	initialBackoff       = 100 * time.Millisecond
+	maxBackoff           = 10 * time.Second
+)
+
+// HTTPBackendOption configures an HTTPBackend.
+type HTTPBackendOption func(*HTTPBackend)
+
+// WithFlushInterval sets how often buffered entries are sent, 5 seconds by default.
+func WithFlushInterval(d time.Duration) HTTPBackendOption {
+	return func(b *HTTPBackend) {
+		if d > 0 {
+			b.interval = d
+		}
+	}
+}
+
+// WithMaxBatchSize sets the maximum number of entries per request, 100 by default.
+// A full batch is sent without waiting for the flush interval.
+func WithMaxBatchSize(n int) HTTPBackendOption {
+	return func(b *HTTPBackend) {
+		if n > 0 {
+			b.maxBatch = n
+		}
+	}
+}
+
+// WithAuthToken sends token as a bearer token in the Authorization header of every request.
+func WithAuthToken(token string) HTTPBackendOption {
+	return func(b *HTTPBackend) {
+		b.token = token
+	}
+}
+
+// WithTLSConfig sets the TLS configuration used to connect to the endpoint.
+func WithTLSConfig(tlsCfg *tls.Config) HTTPBackendOption {
+	return func(b *HTTPBackend) {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = tlsCfg
+		b.client = &http.Client{Transport: transport}
+	}
+}
+
+// HTTPBackend buffers entries and POSTs them to a URL as a JSON array, from a background goroutine.
+// It is an io.Writer for bolog.NewWriterLogger, best used with the "json" format,
+// and a bolog.Hook for shipping the entries of a file logger as well.
+// Failed requests are retried with exponential backoff on network errors, 429 and 5xx responses.
+type HTTPBackend struct {
+	url      string
+	client   *http.Client
+	token    string
+	interval time.Duration
+	maxBatch int
+
+	mu      sync.Mutex
+	pending []json.RawMessage
+	closed  bool
+
+	flush  chan struct{}
+	cancel context.CancelFunc // Cancels the requests of the background goroutine
+	done   chan struct{}
+}
+
+// NewHTTPBackend returns an HTTPBackend sending entries to url.
+// Shutdown must be called to send the remaining entries and stop the background goroutine.
+func NewHTTPBackend(url string, opts ...HTTPBackendOption) *HTTPBackend {
+	b := &HTTPBackend{
+		url:      url,
+		client:   http.DefaultClient,
+		interval: defaultFlushInterval,
+		maxBatch: defaultMaxBatchSize,
+		flush:    make(chan struct{}, 1),
+		done:     make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	b.cancel = cancel
+	go b.run(ctx)
+	return b
+}
+
+// Write buffers p as one entry. A JSON entry is sent as is, any other as a JSON string.
+func (b *HTTPBackend) Write(p []byte) (int, error) {
+	line := bytes.TrimRight(p, "\n")
+	var message json.RawMessage
+	if json.Valid(line) {
+		message = append(json.RawMessage(nil), line...)
+	} else {
+		encoded, err := json.Marshal(string(line))
+		if err != nil {
+			return 0, err
+		}
+		message = encoded
+	}
+	if err := b.enqueue(message); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Fire implements bolog.Hook, buffering entry in the bolog JSON format.
+func (b *HTTPBackend) Fire(entry bolog.Entry) error {
+	data, err := bolog.JSONFormatter{}.Format(entry)
+	if err != nil {
+		return err
+	}
+	return b.enqueue(bytes.TrimRight(data, "\n"))
+}
+
+// Shutdown stops the background goroutine and sends every buffered entry,
+// retrying until they are delivered or ctx is done.
+// It returns an error if entries could not be delivered.
+// A batch the background goroutine is still sending is canceled and sent again,
+// so the endpoint may receive it twice.
+func (b *HTTPBackend) Shutdown(ctx context.Context) error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return ErrShutdown
+	}
+	b.closed = true
+	b.mu.Unlock()
+
+	b.cancel()
+	<-b.done
+	return b.sendPending(ctx)
+}
+
+// Close shuts the backend down without a deadline. It implements io.Closer,
+// so closing a logger created with bolog.NewWriterLogger sends the remaining entries.
+func (b *HTTPBackend) Close() error {
+	return b.Shutdown(context.Background())
+}
+
+// enqueue buffers message and wakes up the background goroutine once a batch is full.
+func (b *HTTPBackend) enqueue(message json.RawMessage) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return ErrShutdown
+	}
+	b.pending = append(b.pending, message)
+	if len(b.pending) >= b.maxBatch {
+		select {
+		case b.flush <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// run sends the buffered entries every flush interval or when a batch is full, until ctx is canceled.
+func (b *HTTPBackend) run(ctx context.Context) {
+	defer close(b.done)
+	ticker := time.NewTicker(b.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-b.flush:
+		case <-ctx.Done():
+			return
+		}
+		if err := b.sendPending(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("Error shipping logs: %v", err)
+		}
+	}
+}
+
+// sendPending sends the buffered entries in batches of at most maxBatch.
+// A batch that fails with a retryable error is put back in front of the buffer.
+func (b *HTTPBackend) sendPending(ctx context.Context) error {
+	var errs []error
+	for {
+		b.mu.Lock()
+		n := min(len(b.pending), b.maxBatch)
+		batch := b.pending[:n:n]
+		b.pending = b.pending[n:]
+		b.mu.Unlock()
+		if n == 0 {
+			return errors.Join(errs...)
+		}
+
+		retry, err := b.send(ctx, batch)
+		if err == nil {
+			continue
+		}
+		if !retry {
+			errs = append(errs, fmt.Errorf("dropping %d entries: %w", len(batch), err))
+			continue
+		}
+		b.mu.Lock()
+		b.pending = append(batch, b.pending...)
+		b.mu.Unlock()
+		return errors.Join(append(errs, err)...)
+	}
+}
+
+// send POSTs batch, retrying with exponential backoff. It reports whether a failure may be retried later.
+func (b *HTTPBackend) send(ctx context.Context, batch []json.RawMessage) (bool, error) {
+	body, err := json.Marshal(batch)
+	if err != nil {
+		return false, err
+	}
+	backoff := initialBackoff
+	for attempt := 0; ; attempt++ {
+		retry, err := b.post(ctx, body)
+		if err == nil || !retry {
+			return retry, err
+		}
+		if attempt == maxRetries {
+			return true, err
+		}
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return true, errors.Join(err, ctx.Err())
+		}
+		backoff = min(backoff*2, maxBackoff)
+	}
+}
+
+// post makes a single request. It reports whether a failure is transient.
+func (b *HTTPBackend) post(ctx context.Context, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if b.token != "" {
+		req.Header.Set("Authorization", "Bearer "+b.token)
+	}
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return true, fmt.Errorf("shipping logs: %w", err)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+	if resp.StatusCode < 300 {
+		return false, nil
+	}
+	err = fmt.Errorf("shipping logs: %s", resp.Status)
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500, err
+}
@@ -0,0 +1,216 @@
+package httpbackend_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Lacolle87/bolog"
+	"github.com/Lacolle87/bolog/httpbackend"
+)
+
+// testServer answers requests with the given statuses in turn, then 200 OK, and records what it received.
+type testServer struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	statuses  []int
+	batches   [][]json.RawMessage // Bodies of every request, failed ones included
+	auth      []string
+	delivered []string // Entries of the successful requests, in order
+	requests  chan struct{}
+}
+
+func newTestServer(t *testing.T, statuses ...int) *testServer {
+	s := &testServer{statuses: statuses, requests: make(chan struct{}, 100)}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *testServer) serve(w http.ResponseWriter, r *http.Request) {
+	var batch []json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.mu.Lock()
+	status := http.StatusOK
+	if len(s.statuses) > 0 {
+		status, s.statuses = s.statuses[0], s.statuses[1:]
+	}
+	s.batches = append(s.batches, batch)
+	s.auth = append(s.auth, r.Header.Get("Authorization"))
+	if status == http.StatusOK {
+		for _, entry := range batch {
+			s.delivered = append(s.delivered, string(entry))
+		}
+	}
+	s.mu.Unlock()
+	w.WriteHeader(status)
+	s.requests <- struct{}{}
+}
+
+// backend returns an HTTPBackend for s that only sends entries when a batch is full or on Shutdown.
+func (s *testServer) backend(opts ...httpbackend.HTTPBackendOption) *httpbackend.HTTPBackend {
+	return httpbackend.NewHTTPBackend(s.URL, append([]httpbackend.HTTPBackendOption{httpbackend.WithFlushInterval(time.Hour)}, opts...)...)
+}
+
+func shutdown(b *httpbackend.HTTPBackend) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return b.Shutdown(ctx)
+}
+
+func TestShutdownDelivery(t *testing.T) {
+	tests := []struct {
+		name      string
+		statuses  []int
+		requests  int
+		delivered bool
+	}{
+		{name: "success", requests: 1, delivered: true},
+		{name: "retried server error", statuses: []int{http.StatusServiceUnavailable}, requests: 2, delivered: true},
+		{name: "retried rate limit", statuses: []int{http.StatusTooManyRequests, http.StatusBadGateway}, requests: 3, delivered: true},
+		{name: "client error not retried", statuses: []int{http.StatusBadRequest}, requests: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer(t, tt.statuses...)
+			b := s.backend()
+			if _, err := b.Write([]byte(`{"msg":"one"}` + "\n")); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := b.Write([]byte("two\n")); err != nil {
+				t.Fatal(err)
+			}
+
+			err := shutdown(b)
+			if tt.delivered && err != nil {
+				t.Fatalf("Shutdown() error = %v", err)
+			}
+			if !tt.delivered && err == nil {
+				t.Fatal("Shutdown() succeeded, want an error for the dropped entries")
+			}
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			if len(s.batches) != tt.requests {
+				t.Errorf("got %d requests, want %d", len(s.batches), tt.requests)
+			}
+			want := []string{`{"msg":"one"}`, `"two"`}
+			if !tt.delivered {
+				want = nil
+			}
+			if len(s.delivered) != len(want) || (len(want) > 0 && (s.delivered[0] != want[0] || s.delivered[1] != want[1])) {
+				t.Errorf("delivered %q, want %q", s.delivered, want)
+			}
+		})
+	}
+}
+
+func TestBatching(t *testing.T) {
+	s := newTestServer(t)
+	b := s.backend(httpbackend.WithMaxBatchSize(2))
+	for _, msg := range []string{"a", "b", "c"} {
+		if _, err := b.Write([]byte(msg)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The first full batch is sent without waiting for the flush interval.
+	select {
+	case <-s.requests:
+	case <-time.After(5 * time.Second):
+		t.Fatal("full batch was not sent")
+	}
+	if err := shutdown(b); err != nil {
+		t.Fatal(err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// Shutdown may cancel the first batch in flight and send it again.
+	first, last := s.batches[0], s.batches[len(s.batches)-1]
+	if len(first) != 2 || len(last) != 1 || string(last[0]) != `"c"` {
+		t.Errorf("got batches %q, want 2 entries then the last one", s.batches)
+	}
+}
+
+func TestRequeueOnShutdown(t *testing.T) {
+	// The background send fails and waits to retry; Shutdown cancels it,
+	// and the batch put back in the buffer is sent by Shutdown itself.
+	s := newTestServer(t, http.StatusServiceUnavailable)
+	b := s.backend(httpbackend.WithMaxBatchSize(1))
+	if _, err := b.Write([]byte("requeued")); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-s.requests:
+	case <-time.After(5 * time.Second):
+		t.Fatal("full batch was not sent")
+	}
+	if err := shutdown(b); err != nil {
+		t.Fatal(err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.delivered) == 0 || s.delivered[len(s.delivered)-1] != `"requeued"` {
+		t.Errorf("delivered %q, want the requeued entry", s.delivered)
+	}
+}
+
+func TestShutdownDeadline(t *testing.T) {
+	s := newTestServer(t, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError)
+	b := s.backend()
+	if _, err := b.Write([]byte("undelivered")); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 150*time.Millisecond)
+	defer cancel()
+	if err := b.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Shutdown() error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestAfterShutdown(t *testing.T) {
+	s := newTestServer(t)
+	b := s.backend()
+	if err := shutdown(b); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.Write([]byte("late")); !errors.Is(err, httpbackend.ErrShutdown) {
+		t.Errorf("Write() error = %v, want ErrShutdown", err)
+	}
+	if err := b.Fire(bolog.Entry{Level: bolog.InfoLevel, Message: "late"}); !errors.Is(err, httpbackend.ErrShutdown) {
+		t.Errorf("Fire() error = %v, want ErrShutdown", err)
+	}
+	if err := shutdown(b); !errors.Is(err, httpbackend.ErrShutdown) {
+		t.Errorf("second Shutdown() error = %v, want ErrShutdown", err)
+	}
+}
+
+func TestHookAndAuthToken(t *testing.T) {
+	s := newTestServer(t)
+	b := s.backend(httpbackend.WithAuthToken("secret"))
+	if err := b.Fire(bolog.Entry{Level: bolog.WarnLevel, Message: "disk almost full", Time: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	if err := shutdown(b); err != nil {
+		t.Fatal(err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.auth) != 1 || s.auth[0] != "Bearer secret" {
+		t.Errorf("Authorization headers = %q, want Bearer secret", s.auth)
+	}
+	var entry map[string]interface{}
+	if len(s.delivered) != 1 || json.Unmarshal([]byte(s.delivered[0]), &entry) != nil {
+		t.Fatalf("delivered %q, want one JSON entry", s.delivered)
+	}
+	if entry["message"] != "disk almost full" {
+		t.Errorf("delivered entry = %v, want message %q", entry, "disk almost full")
+	}
+}
@@ -0,0 +1,165 @@
+// Package httplog provides net/http middleware that logs requests with a bolog logger.
+package httplog
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Lacolle87/bolog"
+)
+
+// RequestIDHeader is the header read and set by WithRequestID.
+const RequestIDHeader = "X-Request-ID"
+
+// HTTPOption configures HTTPMiddleware.
+type HTTPOption func(*options)
+
+type options struct {
+	requestID bool
+	bodySize  bool
+	headers   []string
+}
+
+// WithRequestID logs the request ID taken from the X-Request-ID header, generating one if it is missing.
+// The ID is echoed in the response header and stored in the request context under bolog.RequestIDKey,
+// so handlers can include it with Logger.LogfCtx.
+func WithRequestID() HTTPOption {
+	return func(o *options) {
+		o.requestID = true
+	}
+}
+
+// WithBodySize logs the number of response body bytes written as "bytes".
+func WithBodySize() HTTPOption {
+	return func(o *options) {
+		o.bodySize = true
+	}
+}
+
+// WithHeaders logs the values of the specified request headers as "header.<Name>" fields.
+func WithHeaders(headers ...string) HTTPOption {
+	return func(o *options) {
+		o.headers = append(o.headers, headers...)
+	}
+}
+
+// HTTPMiddleware logs every request once it has been served, with its method, path, status code,
+// duration and remote address as fields. Server errors are logged at ErrorLevel, client errors
+// at WarnLevel and everything else at InfoLevel, in the logger's configured format.
+func HTTPMiddleware(l *bolog.Logger, opts ...HTTPOption) func(http.Handler) http.Handler {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			fields := bolog.Fields{}
+			if o.requestID {
+				id := r.Header.Get(RequestIDHeader)
+				if id == "" {
+					id = newRequestID()
+				}
+				w.Header().Set(RequestIDHeader, id)
+				r = r.WithContext(bolog.NewContextWithField(r.Context(), bolog.RequestIDKey, id))
+				fields[bolog.RequestIDKey] = id
+			}
+
+			rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rec, r)
+
+			fields["method"] = r.Method
+			fields["path"] = r.URL.Path
+			fields["status"] = rec.status
+			fields["duration"] = time.Since(start).String()
+			fields["remote_addr"] = r.RemoteAddr
+			if o.bodySize {
+				fields["bytes"] = rec.bytes
+			}
+			for _, header := range o.headers {
+				if value := r.Header.Get(header); value != "" {
+					fields["header."+http.CanonicalHeaderKey(header)] = value
+				}
+			}
+
+			logger := l.WithFields(fields)
+			switch {
+			case rec.status >= 500:
+				logger.Errorf("%s %s %d", r.Method, r.URL.Path, rec.status)
+			case rec.status >= 400:
+				logger.Warnf("%s %s %d", r.Method, r.URL.Path, rec.status)
+			default:
+				logger.Infof("%s %s %d", r.Method, r.URL.Path, rec.status)
+			}
+		})
+	}
+}
+
+// RecoverMiddleware recovers from panics in the next handler, logs the panic value with the stack trace
+// at ErrorLevel and responds with 500 Internal Server Error.
+// http.ErrAbortHandler is re-panicked so that net/http can abort the response as intended.
+func RecoverMiddleware(l *bolog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				rec := recover()
+				if rec == nil {
+					return
+				}
+				if rec == http.ErrAbortHandler {
+					panic(rec)
+				}
+				l.WithFields(bolog.Fields{"method": r.Method, "path": r.URL.Path}).LogError(fmt.Errorf("panic: %v", rec))
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			}()
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// statusRecorder captures the status code and body size written by a handler.
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	bytes       int
+	wroteHeader bool
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(p []byte) (int, error) {
+	r.wroteHeader = true
+	n, err := r.ResponseWriter.Write(p)
+	r.bytes += n
+	return n, err
+}
+
+// Flush implements http.Flusher if the underlying writer does.
+func (r *statusRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// newRequestID returns a random 16-byte hex request ID.
+func newRequestID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b[:])
+}
@@ -0,0 +1,64 @@
+package bolog
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Level represents the severity of a log entry.
+type Level int32
+
+// Supported log levels, ordered from least to most severe.
+const (
+	DebugLevel Level = iota
+	InfoLevel
+	WarnLevel
+	ErrorLevel
+	FatalLevel
+)
+
+// String returns the upper-case tag of the level, e.g. "INFO".
+func (lv Level) String() string {
+	switch lv {
+	case DebugLevel:
+		return "DEBUG"
+	case InfoLevel:
+		return "INFO"
+	case WarnLevel:
+		return "WARN"
+	case ErrorLevel:
+		return "ERROR"
+	case FatalLevel:
+		return "FATAL"
+	default:
+		return fmt.Sprintf("LEVEL(%d)", int32(lv))
+	}
+}
+
+// ParseLevel converts a level name such as "info" or "WARN" into a Level.
+func ParseLevel(name string) (Level, error) {
+	switch strings.ToUpper(strings.TrimSpace(name)) {
+	case "DEBUG":
+		return DebugLevel, nil
+	case "INFO":
+		return InfoLevel, nil
+	case "WARN", "WARNING":
+		return WarnLevel, nil
+	case "ERROR":
+		return ErrorLevel, nil
+	case "FATAL":
+		return FatalLevel, nil
+	default:
+		return InfoLevel, fmt.Errorf("unknown log level %q", name)
+	}
+}
+
+// getLevel returns the Level for the specified name,
+// defaulting to InfoLevel if the name is empty or invalid.
+func getLevel(name string) Level {
+	level, err := ParseLevel(name)
+	if err != nil {
+		return InfoLevel
+	}
+	return level
+}
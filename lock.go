@@ -0,0 +1,57 @@
+package bolog
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ErrLogFileLocked is returned by SetupLogger with ExclusiveLock when another process
+// holds the lock of the log files beyond LockTimeout.
+var ErrLogFileLocked = errors.New("bolog: log file is locked by another process")
+
+// lockRetryInterval is how often a held lock is tried again until LockTimeout.
+const lockRetryInterval = 50 * time.Millisecond
+
+// lockPath returns the lock file of the log files of config. It is named after the files
+// with the zero time, e.g. "log_00010101.txt.lock", so that it stays the same across periods and rotations.
+func lockPath(config ConfigLogger) string {
+	return filepath.Join(config.LogDir, fileNamer(config).FileName(time.Time{})+".lock")
+}
+
+// acquireLock creates the lock file of config and takes an advisory exclusive lock on it,
+// flock on Unix and LockFileEx on Windows, retrying until LockTimeout.
+// The lock is released by closing the returned file.
+func acquireLock(config ConfigLogger) (*os.File, error) {
+	path := lockPath(config)
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, config.FilePerm)
+	if err != nil {
+		return nil, err
+	}
+	deadline := time.Now().Add(config.LockTimeout)
+	for {
+		err := tryLock(file)
+		if err == nil {
+			return file, nil
+		}
+		if !errors.Is(err, ErrLogFileLocked) || !time.Now().Before(deadline) {
+			file.Close()
+			return nil, fmt.Errorf("locking %s: %w", path, err)
+		}
+		time.Sleep(min(lockRetryInterval, time.Until(deadline)))
+	}
+}
+
+// unlock releases the lock taken with ExclusiveLock, if any.
+func (c *core) unlock() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.lock == nil {
+		return nil
+	}
+	err := c.lock.Close()
+	c.lock = nil
+	return err
+}
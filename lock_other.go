@@ -0,0 +1,13 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd || windows)
+
+package bolog
+
+import (
+	"errors"
+	"os"
+)
+
+// tryLock reports that file locking is not supported on this platform.
+func tryLock(file *os.File) error {
+	return errors.ErrUnsupported
+}
@@ -0,0 +1,35 @@
+package bolog
+
+import "time"
+
+// ErrorKey is the field under which LogEvent records LogEvent.Error.
+const ErrorKey = "error"
+
+// LogEvent is a structured application event written by Logger.LogEvent.
+type LogEvent struct {
+	Level     Level
+	Message   string
+	Fields    map[string]interface{} // Merged with the logger's fields, taking precedence over them
+	Error     error                  // Written as the "error" field when set
+	Timestamp time.Time              // Time of the event, the time it is logged if zero
+}
+
+// LogEvent writes e without formatting a message, skipping it if its level is below the logger's minimum level.
+// The message defaults to the error's text if it is empty.
+// As with Fatalf, an event at FatalLevel closes the log file and then calls os.Exit(1).
+func (l *Logger) LogEvent(e LogEvent) {
+	if e.Level == FatalLevel {
+		defer l.exit()
+	}
+	if !l.enabled(e.Level) {
+		return
+	}
+	fields := Fields(e.Fields)
+	if e.Error != nil {
+		fields = mergeFields(fields, Fields{ErrorKey: e.Error.Error()})
+		if e.Message == "" {
+			e.Message = e.Error.Error()
+		}
+	}
+	l.write(Entry{Time: e.Timestamp, Level: e.Level, Message: e.Message, Fields: fields})
+}
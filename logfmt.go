@@ -0,0 +1,109 @@
+package bolog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// LogfmtFormatter writes entries as logfmt key=value pairs on a single line, e.g.
+// `ts=2024-01-02T15:04:05Z level=INFO msg="user logged in" user_id=42`, parseable by go-logfmt:
+// "ts" in RFC 3339, "level" and "msg", followed when set by "seq", "elapsed_ms", "hostname", "pid",
+// "caller" and "stack" and the entry fields. Values that are empty or contain spaces, "=", quotes
+// or control characters are quoted, and characters not allowed in keys are replaced by "_".
+type LogfmtFormatter struct{}
+
+// Format implements Formatter.
+func (LogfmtFormatter) Format(entry Entry) ([]byte, error) {
+	var buf bytes.Buffer
+	writeLogfmtPair(&buf, "ts", entry.Time.Format(time.RFC3339))
+	writeLogfmtPair(&buf, "level", entry.Level.String())
+	writeLogfmtPair(&buf, "msg", entry.Message)
+	if entry.Seq > 0 {
+		writeLogfmtPair(&buf, "seq", entry.Seq)
+	}
+	if entry.Elapsed != 0 {
+		writeLogfmtPair(&buf, "elapsed_ms", entry.Elapsed.Milliseconds())
+	}
+	if entry.Hostname != "" {
+		writeLogfmtPair(&buf, "hostname", entry.Hostname)
+	}
+	if entry.PID != 0 {
+		writeLogfmtPair(&buf, "pid", entry.PID)
+	}
+	if entry.Caller != "" {
+		writeLogfmtPair(&buf, "caller", entry.Caller)
+	}
+	if entry.Stack != "" {
+		writeLogfmtPair(&buf, "stack", entry.Stack)
+	}
+	for _, key := range sortedKeys(entry.Fields) {
+		name := logfmtKey(key)
+		if isReservedJSONKey(name) || name == "ts" || name == "msg" {
+			name = "fields." + name
+		}
+		writeLogfmtPair(&buf, name, entry.Fields[key])
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+// writeLogfmtPair appends key=value to buf, separated from a previous pair by a space.
+func writeLogfmtPair(buf *bytes.Buffer, key string, value interface{}) {
+	if buf.Len() > 0 {
+		buf.WriteByte(' ')
+	}
+	buf.WriteString(key)
+	buf.WriteByte('=')
+
+	var s string
+	switch v := value.(type) {
+	case string:
+		s = v
+	case error:
+		s = v.Error()
+	case fmt.Stringer:
+		s = v.String()
+	default:
+		s = fmt.Sprint(v)
+	}
+	if !logfmtNeedsQuotes(s) {
+		buf.WriteString(s)
+		return
+	}
+	// go-logfmt unquotes values with the JSON string escapes.
+	var quoted bytes.Buffer
+	encoder := json.NewEncoder(&quoted)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(s)
+	buf.Write(bytes.TrimSuffix(quoted.Bytes(), newline))
+}
+
+// logfmtNeedsQuotes reports whether s must be quoted to be read back as a single logfmt value.
+func logfmtNeedsQuotes(s string) bool {
+	if s == "" {
+		return true
+	}
+	for _, r := range s {
+		if r <= ' ' || r == '=' || r == '"' || r == utf8.RuneError {
+			return true
+		}
+	}
+	return false
+}
+
+// logfmtKey replaces the characters of key that logfmt does not allow in keys with "_".
+func logfmtKey(key string) string {
+	if key == "" {
+		return "_"
+	}
+	return strings.Map(func(r rune) rune {
+		if r <= ' ' || r == '=' || r == '"' || r == utf8.RuneError {
+			return '_'
+		}
+		return r
+	}, key)
+}
@@ -0,0 +1,76 @@
+package bolog
+
+import (
+	"io"
+	"strings"
+	"sync"
+)
+
+// MemoryLogger is a Logger that keeps every entry in memory instead of writing it to a file,
+// so tests can assert on what was logged. It logs at DebugLevel unless SetLevel is called,
+// and can be passed wherever a *Logger is expected through its embedded Logger.
+type MemoryLogger struct {
+	*Logger
+
+	mu      sync.Mutex
+	entries []Entry
+}
+
+// NewMemoryLogger returns an empty MemoryLogger.
+func NewMemoryLogger() *MemoryLogger {
+	m := &MemoryLogger{Logger: NewWriterLogger(io.Discard, WithLevel(DebugLevel))}
+	m.AddHook(memoryHook{m})
+	return m
+}
+
+// Entries returns a copy of the entries logged so far, oldest first.
+func (m *MemoryLogger) Entries() []Entry {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Entry(nil), m.entries...)
+}
+
+// EntriesAtLevel returns the entries logged at exactly level, oldest first.
+func (m *MemoryLogger) EntriesAtLevel(level Level) []Entry {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var entries []Entry
+	for _, entry := range m.entries {
+		if entry.Level == level {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// ContainsMessage reports whether any entry logged so far has a message containing substr.
+func (m *MemoryLogger) ContainsMessage(substr string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, entry := range m.entries {
+		if strings.Contains(entry.Message, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+// Reset discards the entries logged so far.
+func (m *MemoryLogger) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries = nil
+}
+
+// memoryHook records the entries of a MemoryLogger.
+type memoryHook struct {
+	m *MemoryLogger
+}
+
+// Fire implements Hook.
+func (h memoryHook) Fire(entry Entry) error {
+	h.m.mu.Lock()
+	defer h.m.mu.Unlock()
+	h.m.entries = append(h.m.entries, entry)
+	return nil
+}
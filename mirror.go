@@ -0,0 +1,57 @@
+package bolog
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// newMirrorFile returns a rotatingFile writing copies of the entries of config's log file to dir,
+// for AdditionalLogDirs, named after now. It rotates on its own, with the same settings.
+func newMirrorFile(config ConfigLogger, dir string, now time.Time) *rotatingFile {
+	config.LogDir = dir
+	config.AdditionalLogDirs = nil
+	file := newLumberjack(config, filepath.Join(dir, getLogFileName(config, now)))
+	return newRotatingFile(file, config, now)
+}
+
+// addMirrors creates the AdditionalLogDirs of config and their mirror files.
+// A directory that cannot be created is reported to onMirrorError; writes to it are attempted regardless.
+func (r *rotatingFile) addMirrors(config ConfigLogger) {
+	now := time.Now().In(r.location)
+	for _, dir := range config.AdditionalLogDirs {
+		if err := os.MkdirAll(dir, config.DirPerm); err != nil {
+			r.mirrorFailed(err)
+		}
+		mirror := newMirrorFile(config, dir, now)
+		mirror.header = r.header
+		r.mirrors = append(r.mirrors, mirror)
+	}
+}
+
+// mirror writes p to every mirror file. Failures are reported to onMirrorError and never returned,
+// so that they do not affect the primary file.
+func (r *rotatingFile) mirror(p []byte) {
+	for _, m := range r.mirrors {
+		if _, err := m.Write(p); err != nil {
+			r.mirrorFailed(fmt.Errorf("writing to %s: %w", m.config.LogDir, err))
+		}
+	}
+}
+
+// eachMirror calls fn for every mirror file, reporting its failures to onMirrorError.
+func (r *rotatingFile) eachMirror(fn func(m *rotatingFile) error) {
+	for _, m := range r.mirrors {
+		if err := fn(m); err != nil {
+			r.mirrorFailed(fmt.Errorf("%s: %w", m.config.LogDir, err))
+		}
+	}
+}
+
+// mirrorFailed passes err to onMirrorError, if set.
+func (r *rotatingFile) mirrorFailed(err error) {
+	if r.onMirrorError != nil {
+		r.onMirrorError(err)
+	}
+}
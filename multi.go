@@ -0,0 +1,155 @@
+package bolog
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"runtime/debug"
+)
+
+// LeveledLogger is the logging interface shared by *Logger and the types
+// that fan out to or stand in for it.
+type LeveledLogger interface {
+	Logf(format string, v ...interface{})
+	Debugf(format string, v ...interface{})
+	Infof(format string, v ...interface{})
+	Warnf(format string, v ...interface{})
+	Errorf(format string, v ...interface{})
+	Fatalf(format string, v ...interface{})
+	LogInfo(msg string)
+	LogError(err error)
+	LogFatal(err error)
+}
+
+var (
+	_ LeveledLogger = (*Logger)(nil)
+	_ LeveledLogger = (*MultiLogger)(nil)
+	_ LeveledLogger = (*MemoryLogger)(nil)
+	_ LeveledLogger = (*Pipeline)(nil)
+)
+
+// MultiLogger writes every entry to several loggers in sequence, each applying its own level and format.
+type MultiLogger struct {
+	loggers []*Logger
+}
+
+// NewMultiLogger returns a MultiLogger writing to all the given loggers.
+func NewMultiLogger(loggers ...*Logger) *MultiLogger {
+	return &MultiLogger{loggers: append([]*Logger(nil), loggers...)}
+}
+
+// Log writes a formatted message at the given level to every logger whose minimum level allows it.
+// Errors from individual loggers do not stop the others and are returned joined.
+func (m *MultiLogger) Log(level Level, format string, v ...interface{}) error {
+	message := fmt.Sprintf(format, v...)
+	return m.each(level, func(l *Logger) error {
+		return l.writeEntry(level, message, nil)
+	})
+}
+
+// Logf logs a formatted message at InfoLevel to every logger.
+func (m *MultiLogger) Logf(format string, v ...interface{}) {
+	_ = m.Log(InfoLevel, format, v...)
+}
+
+// Debugf logs a formatted message at DebugLevel to every logger.
+func (m *MultiLogger) Debugf(format string, v ...interface{}) {
+	_ = m.Log(DebugLevel, format, v...)
+}
+
+// Infof logs a formatted message at InfoLevel to every logger.
+func (m *MultiLogger) Infof(format string, v ...interface{}) {
+	_ = m.Log(InfoLevel, format, v...)
+}
+
+// Warnf logs a formatted message at WarnLevel to every logger.
+func (m *MultiLogger) Warnf(format string, v ...interface{}) {
+	_ = m.Log(WarnLevel, format, v...)
+}
+
+// Errorf logs a formatted message at ErrorLevel to every logger.
+func (m *MultiLogger) Errorf(format string, v ...interface{}) {
+	_ = m.Log(ErrorLevel, format, v...)
+}
+
+// Fatalf logs a formatted message at FatalLevel to every logger, closes them and then calls os.Exit(1).
+func (m *MultiLogger) Fatalf(format string, v ...interface{}) {
+	_ = m.Log(FatalLevel, format, v...)
+	m.exit()
+}
+
+// LogInfo logs msg unformatted at InfoLevel to every logger.
+func (m *MultiLogger) LogInfo(msg string) {
+	_ = m.each(InfoLevel, func(l *Logger) error {
+		return l.writeEntry(InfoLevel, msg, nil)
+	})
+}
+
+// LogError logs err at ErrorLevel with a stack trace to every logger. A nil error is ignored.
+func (m *MultiLogger) LogError(err error) {
+	if err != nil {
+		m.logStack(ErrorLevel, err)
+	}
+}
+
+// LogFatal logs err at FatalLevel with a stack trace to every logger, closes them and then calls os.Exit(1).
+func (m *MultiLogger) LogFatal(err error) {
+	if err != nil {
+		m.logStack(FatalLevel, err)
+	}
+	m.exit()
+}
+
+// Write writes p unchanged with the Write method of every logger, which drops it for disabled loggers
+// and fails for shut down ones, and returns the write errors joined.
+func (m *MultiLogger) Write(p []byte) (int, error) {
+	var errs []error
+	for _, l := range m.loggers {
+		if _, err := l.Write(p); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return len(p), errors.Join(errs...)
+}
+
+// Close closes every logger and returns the errors joined. It implements io.Closer.
+func (m *MultiLogger) Close() error {
+	var errs []error
+	for _, l := range m.loggers {
+		if err := l.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// logStack writes err with the current stack trace to every logger.
+func (m *MultiLogger) logStack(level Level, err error) {
+	stack := string(debug.Stack())
+	_ = m.each(level, func(l *Logger) error {
+		return l.write(Entry{Level: level, Message: err.Error(), Stack: stack})
+	})
+}
+
+// each calls fn for every logger whose minimum level is at or below level and joins the errors.
+func (m *MultiLogger) each(level Level, fn func(l *Logger) error) error {
+	var errs []error
+	for _, l := range m.loggers {
+		if !l.enabled(level) {
+			continue
+		}
+		if err := fn(l); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// exit flushes and closes every logger and terminates the process.
+func (m *MultiLogger) exit() {
+	for _, l := range m.loggers {
+		_ = l.flushOutput()
+	}
+	_ = m.Close()
+	os.Exit(1)
+}
@@ -0,0 +1,117 @@
+package bolog
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// defaultFilenameTemplate is the time layout of log file names when no template is configured.
+const defaultFilenameTemplate = "log_20060102.txt"
+
+// rotateByTemplates are the file name layouts used by the RotateBy schemes other than "daily"
+// when FilenameTemplate is left at its default.
+var rotateByTemplates = map[string]string{
+	"hourly":  "log_20060102_15.txt",
+	"monthly": "log_200601.txt",
+	"weekday": "log_Monday.txt",
+}
+
+// intervalTemplates are the file name layouts used by the rotation intervals longer than a day
+// when FilenameTemplate is left at its default and RotateBy implies no other layout.
+// A weekly file is named after its ISO week by isoWeekNamer instead.
+var intervalTemplates = map[string]string{
+	"monthly": "log_200601.txt",
+}
+
+// rotateByIntervals are the rotation intervals implied by the RotateBy schemes when RotateInterval is unset.
+// "daily" implies none, since its files only change name at midnight when written to.
+var rotateByIntervals = map[string]string{
+	"hourly":  "hourly",
+	"monthly": "monthly",
+	"weekday": "daily",
+}
+
+// FileNamer is a strategy naming the log file opened at t, relative to LogDir.
+// Set ConfigLogger.FileNamer to replace the built-in naming schemes.
+type FileNamer interface {
+	FileName(t time.Time) string
+}
+
+// TemplateNamer is a FileNamer formatting the time with a time.Format layout such as "log_20060102.txt".
+type TemplateNamer string
+
+// FileName implements FileNamer.
+func (n TemplateNamer) FileName(t time.Time) string {
+	return t.Format(string(n))
+}
+
+// isoWeekNamer is the FileNamer of weekly rotation with the default template,
+// naming files after the ISO 8601 week, e.g. "log_2024W01.txt", since time.Format has no week layout.
+type isoWeekNamer struct{}
+
+// FileName implements FileNamer.
+func (isoWeekNamer) FileName(t time.Time) string {
+	year, week := t.ISOWeek()
+	return fmt.Sprintf("log_%04dW%02d.txt", year, week)
+}
+
+// fileNamer returns the naming strategy of config: its FileNamer if set, and otherwise FilenameTemplate,
+// replaced by the layout of RotateBy, or else of RotateInterval, if the template is the default.
+func fileNamer(config ConfigLogger) FileNamer {
+	if config.FileNamer != nil {
+		return config.FileNamer
+	}
+	template := config.FilenameTemplate
+	if template == "" || template == defaultFilenameTemplate {
+		template = defaultFilenameTemplate
+		interval := strings.ToLower(config.RotateInterval)
+		if layout, ok := rotateByTemplates[strings.ToLower(config.RotateBy)]; ok {
+			template = layout
+		} else if interval == "weekly" {
+			return isoWeekNamer{}
+		} else if layout, ok := intervalTemplates[interval]; ok {
+			template = layout
+		}
+	}
+	return TemplateNamer(template)
+}
+
+// rotationInterval returns RotateInterval or, if it is unset, the interval implied by RotateBy.
+func rotationInterval(config ConfigLogger) string {
+	if config.RotateInterval != "" {
+		return strings.ToLower(config.RotateInterval)
+	}
+	return rotateByIntervals[strings.ToLower(config.RotateBy)]
+}
+
+// validRotateBy reports whether scheme is a supported RotateBy value.
+func validRotateBy(scheme string) bool {
+	switch strings.ToLower(scheme) {
+	case "", "daily", "hourly", "monthly", "weekday":
+		return true
+	}
+	return false
+}
+
+// validOpenMode reports whether mode is a supported OpenMode value.
+func validOpenMode(mode string) bool {
+	switch strings.ToLower(mode) {
+	case "", "append", "new", "rotate":
+		return true
+	}
+	return false
+}
+
+// discardStale removes the current file if it was last written before the current period started,
+// so that with RotateBy "weekday" each file only holds the latest week's entries for its day.
+func (r *rotatingFile) discardStale() {
+	if strings.ToLower(r.config.RotateBy) != "weekday" || !builtinNamer(r.config.FileNamer) {
+		return
+	}
+	period := periodStart(time.Now().In(r.location), "daily")
+	if info, err := os.Stat(r.file.Filename); err == nil && info.ModTime().Before(period) {
+		_ = os.Remove(r.file.Filename)
+	}
+}
@@ -0,0 +1,12 @@
+package bolog
+
+import "io"
+
+// NopLogger returns a logger that discards everything written to it, for tests and optional logging.
+// Entries are dropped before they are formatted, so logging allocates nothing beyond the call's arguments.
+// Fatalf and LogFatal still terminate the process.
+func NopLogger() *Logger {
+	logger := NewWriterLogger(io.Discard)
+	logger.Disable()
+	return logger
+}
@@ -0,0 +1,297 @@
+package bolog
+
+import (
+	"os"
+	"time"
+)
+
+// Option configures a logger created with NewLogger.
+type Option func(*ConfigLogger)
+
+// WithConfig replaces the whole configuration with config.
+// Options given after it override individual fields.
+func WithConfig(config ConfigLogger) Option {
+	return func(c *ConfigLogger) {
+		*c = config
+	}
+}
+
+// WithLogDir sets the directory for storing logs.
+func WithLogDir(dir string) Option {
+	return func(c *ConfigLogger) {
+		c.LogDir = dir
+	}
+}
+
+// WithMaxSize sets the maximum log file size in megabytes.
+func WithMaxSize(mb int) Option {
+	return func(c *ConfigLogger) {
+		c.MaxSize = mb
+	}
+}
+
+// WithMaxLines rotates the log file once it holds n lines, or MaxSize is reached, whichever comes first.
+func WithMaxLines(n int) Option {
+	return func(c *ConfigLogger) {
+		c.MaxLines = n
+	}
+}
+
+// WithMaxBackups sets the maximum number of old log files to retain.
+func WithMaxBackups(n int) Option {
+	return func(c *ConfigLogger) {
+		c.MaxBackups = n
+	}
+}
+
+// WithMaxAge sets the maximum number of days to retain old log files.
+func WithMaxAge(days int) Option {
+	return func(c *ConfigLogger) {
+		c.MaxAge = days
+	}
+}
+
+// WithCompression enables or disables compression of old log files.
+func WithCompression(enabled bool) Option {
+	return func(c *ConfigLogger) {
+		c.Compress = enabled
+	}
+}
+
+// WithTimezone sets the timezone used for timestamps and file names.
+func WithTimezone(tz string) Option {
+	return func(c *ConfigLogger) {
+		c.Timezone = tz
+	}
+}
+
+// WithLevel sets the minimum level of entries written.
+func WithLevel(level Level) Option {
+	return func(c *ConfigLogger) {
+		c.Level = level.String()
+	}
+}
+
+// WithFormat sets the output format, "text", "json", "ndjson", "gelf", "logfmt" or "csv".
+func WithFormat(format string) Option {
+	return func(c *ConfigLogger) {
+		c.Format = format
+	}
+}
+
+// WithCaller reports the location of the logging call in every entry.
+// A depth of 1 is the immediate caller; wrappers can pass a higher depth to skip their own frames.
+func WithCaller(depth int) Option {
+	return func(c *ConfigLogger) {
+		c.CallerDepth = depth
+	}
+}
+
+// WithRotateInterval starts a new log file every "hourly", "daily", "weekly" (on Mondays) or "monthly" period.
+// With the default FilenameTemplate, weekly files are named like "log_2024W01.txt" and monthly ones like "log_202401.txt".
+func WithRotateInterval(interval string) Option {
+	return func(c *ConfigLogger) {
+		c.RotateInterval = interval
+	}
+}
+
+// WithFilenameTemplate sets the time.Format layout used to name log files, e.g. "app_2006-01-02_15.log".
+func WithFilenameTemplate(template string) Option {
+	return func(c *ConfigLogger) {
+		c.FilenameTemplate = template
+	}
+}
+
+// WithTimestampFormat sets the time.Format layout of entry timestamps, or TimestampUnixMilli for epoch milliseconds.
+func WithTimestampFormat(layout string) Option {
+	return func(c *ConfigLogger) {
+		c.TimestampFormat = layout
+	}
+}
+
+// WithSequenceNumbers numbers entries from 1, restarting in every new file, so gaps can be detected.
+func WithSequenceNumbers(enabled bool) Option {
+	return func(c *ConfigLogger) {
+		c.SequenceNumbers = enabled
+	}
+}
+
+// WithHostname adds the host name, looked up once, to every entry.
+func WithHostname(enabled bool) Option {
+	return func(c *ConfigLogger) {
+		c.IncludeHostname = enabled
+	}
+}
+
+// WithPID adds the process ID to every entry.
+func WithPID(enabled bool) Option {
+	return func(c *ConfigLogger) {
+		c.IncludePID = enabled
+	}
+}
+
+// WithBufferSize buffers up to size bytes in memory before writing them to the file.
+// Buffered entries are written out by Flush, Close and before every rotation.
+func WithBufferSize(size int) Option {
+	return func(c *ConfigLogger) {
+		c.BufferSize = size
+	}
+}
+
+// WithDedupeWindow suppresses consecutive identical messages within window,
+// writing a "[previous message repeated N times]" summary once the run ends.
+func WithDedupeWindow(window time.Duration) Option {
+	return func(c *ConfigLogger) {
+		c.DedupeWindow = window
+	}
+}
+
+// WithSampleRate writes only every nth entry, every 100th for 100, counting the others in SampledCount.
+// Unlike WithRateLimit it keeps a fixed proportion of the entries however fast they are logged.
+func WithSampleRate(n int) Option {
+	return func(c *ConfigLogger) {
+		c.SampleRate = n
+	}
+}
+
+// WithMaxTotalMB caps the total size of the log files in megabytes, deleting the oldest backups after rotation.
+func WithMaxTotalMB(megabytes int) Option {
+	return func(c *ConfigLogger) {
+		c.MaxTotalMB = megabytes
+	}
+}
+
+// WithCurrentSymlink keeps a symlink with the specified name in LogDir pointing to the current log file.
+func WithCurrentSymlink(name string) Option {
+	return func(c *ConfigLogger) {
+		c.CurrentSymlink = name
+	}
+}
+
+// WithMaxMessageBytes truncates messages longer than max bytes, marking them with "...[truncated]".
+func WithMaxMessageBytes(max int) Option {
+	return func(c *ConfigLogger) {
+		c.MaxMessageBytes = max
+	}
+}
+
+// WithElapsed adds the time since the logger was created to every entry.
+func WithElapsed(enabled bool) Option {
+	return func(c *ConfigLogger) {
+		c.IncludeElapsed = enabled
+	}
+}
+
+// WithOnWriteError calls fn with every error writing an entry, instead of reporting it with log.Printf.
+func WithOnWriteError(fn func(err error)) Option {
+	return func(c *ConfigLogger) {
+		c.OnWriteError = fn
+	}
+}
+
+// WithWriteRetries retries a failed write to the log file up to retries times, delay apart,
+// giving up once timeout has passed since the first attempt if timeout is positive.
+// A positive timeout also bounds how long logging waits for a blocked write, see ErrWriteTimeout.
+func WithWriteRetries(retries int, delay, timeout time.Duration) Option {
+	return func(c *ConfigLogger) {
+		c.WriteRetries = retries
+		c.WriteRetryDelay = delay
+		c.WriteTimeout = timeout
+	}
+}
+
+// WithRotateBy sets the file naming scheme, "daily", "hourly", "monthly" or "weekday",
+// e.g. "log_Monday.txt" for "weekday", where each file is overwritten a week later.
+func WithRotateBy(scheme string) Option {
+	return func(c *ConfigLogger) {
+		c.RotateBy = scheme
+	}
+}
+
+// WithFileHeader writes the text returned by header, such as DefaultFileHeader, at the top of every new file.
+func WithFileHeader(header func(config ConfigLogger) string) Option {
+	return func(c *ConfigLogger) {
+		c.FileHeader = header
+	}
+}
+
+// WithCompressAlgo sets the compression of old log files, "gzip" or "zstd".
+func WithCompressAlgo(algo string) Option {
+	return func(c *ConfigLogger) {
+		c.CompressAlgo = algo
+	}
+}
+
+// WithCompressOnWrite gzips log files as they are written, so they are never stored uncompressed.
+func WithCompressOnWrite(enabled bool) Option {
+	return func(c *ConfigLogger) {
+		c.CompressOnWrite = enabled
+	}
+}
+
+// WithChecksum writes the SHA-256 digest of every completed log file to a ".sha256" file next to it.
+func WithChecksum(enabled bool) Option {
+	return func(c *ConfigLogger) {
+		c.Checksum = enabled
+	}
+}
+
+// WithEncryptionKey encrypts every entry with AES-256-GCM using key, which must be 32 bytes.
+// Use NewDecryptingReader or NewLogReaderWithKey to read the files.
+func WithEncryptionKey(key []byte) Option {
+	return func(c *ConfigLogger) {
+		c.EncryptionKey = key
+	}
+}
+
+// WithOpenMode sets how the log file is opened on startup: "append" continues the existing file,
+// "new" starts a fresh one named with the start time, e.g. "log_20240101-150405.txt",
+// and "rotate" rotates the existing file before writing to it.
+func WithOpenMode(mode string) Option {
+	return func(c *ConfigLogger) {
+		c.OpenMode = mode
+	}
+}
+
+// WithLocalBackupTime names rotated backups with the time in Timezone instead of UTC,
+// e.g. "log_20240101-2024-01-01T09-30-00.000.txt" at 9:30 local time.
+func WithLocalBackupTime(enabled bool) Option {
+	return func(c *ConfigLogger) {
+		c.LocalBackupTime = enabled
+	}
+}
+
+// WithArchiveByDate moves log files from previous days to LogDir/2006/01/02 subdirectories,
+// on startup and after every time-based rotation.
+func WithArchiveByDate(enabled bool) Option {
+	return func(c *ConfigLogger) {
+		c.ArchiveByDate = enabled
+	}
+}
+
+// WithAdditionalLogDirs writes a copy of every entry to a log file in each of dirs, best-effort:
+// failed writes to them are reported to OnWriteError and do not affect the primary file.
+func WithAdditionalLogDirs(dirs ...string) Option {
+	return func(c *ConfigLogger) {
+		c.AdditionalLogDirs = dirs
+	}
+}
+
+// WithExclusiveLock locks the log files for the logger, failing SetupLogger with ErrLogFileLocked
+// if another process holds the lock for longer than timeout, e.g. during a rolling deploy.
+// The lock is advisory: it only excludes processes that lock the files too.
+func WithExclusiveLock(timeout time.Duration) Option {
+	return func(c *ConfigLogger) {
+		c.ExclusiveLock = true
+		c.LockTimeout = timeout
+	}
+}
+
+// WithPermissions sets the permissions of the created log directories and of new log files,
+// e.g. 0700 and 0600 to restrict access to the owner.
+func WithPermissions(dirPerm, filePerm os.FileMode) Option {
+	return func(c *ConfigLogger) {
+		c.DirPerm = dirPerm
+		c.FilePerm = filePerm
+	}
+}
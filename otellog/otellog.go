@@ -0,0 +1,43 @@
+// Package otellog adds OpenTelemetry trace context to bolog entries.
+package otellog
+
+import (
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Lacolle87/bolog"
+)
+
+// NewOTelHook returns a hook that adds the trace and span IDs of the span in an entry's context
+// as the bolog.TraceIDKey and bolog.SpanIDKey fields. Entries get a context from
+// Logger.LogfCtx or Logger.ForRequest; entries without a valid span are left unchanged.
+// Text output shows the IDs as "[trace:X span:Y]" after the message, JSON as "trace_id" and "span_id".
+func NewOTelHook() bolog.Hook {
+	return otelHook{}
+}
+
+// otelHook implements bolog.TransformHook.
+type otelHook struct{}
+
+// Fire implements bolog.Hook.
+func (otelHook) Fire(bolog.Entry) error {
+	return nil
+}
+
+// Transform implements bolog.TransformHook.
+func (otelHook) Transform(entry bolog.Entry) bolog.Entry {
+	if entry.Context == nil {
+		return entry
+	}
+	spanContext := trace.SpanContextFromContext(entry.Context)
+	if !spanContext.IsValid() {
+		return entry
+	}
+	fields := make(bolog.Fields, len(entry.Fields)+2)
+	for key, value := range entry.Fields {
+		fields[key] = value
+	}
+	fields[bolog.TraceIDKey] = spanContext.TraceID().String()
+	fields[bolog.SpanIDKey] = spanContext.SpanID().String()
+	entry.Fields = fields
+	return entry
+}
@@ -0,0 +1,18 @@
+package bolog
+
+// Transform rewrites an entry before it is written, e.g. to scrub personal data.
+type Transform func(entry Entry) Entry
+
+// Pipeline is a Logger that passes every entry through a chain of transforms
+// once it is complete, before hooks see it and it is formatted.
+type Pipeline struct {
+	*Logger
+}
+
+// NewPipeline returns a Pipeline writing to the file of base, applying transforms in order
+// after any transforms of base itself.
+func NewPipeline(base *Logger, transforms ...Transform) *Pipeline {
+	logger := base.derive()
+	logger.transforms = append(append([]Transform(nil), base.transforms...), transforms...)
+	return &Pipeline{Logger: logger}
+}
@@ -0,0 +1,37 @@
+package bolog
+
+import (
+	"fmt"
+	"strings"
+)
+
+// StdLogger is the printing interface of the standard library's log.Logger, implemented by *Logger.
+type StdLogger interface {
+	Print(v ...interface{})
+	Printf(format string, v ...interface{})
+	Println(v ...interface{})
+}
+
+var _ StdLogger = (*Logger)(nil)
+
+// Print logs the operands formatted as by fmt.Sprint at InfoLevel.
+func (l *Logger) Print(v ...interface{}) {
+	if !l.enabled(InfoLevel) {
+		return
+	}
+	l.writeEntry(InfoLevel, fmt.Sprint(v...), nil)
+}
+
+// Printf logs a formatted message at InfoLevel, like Logf.
+func (l *Logger) Printf(format string, v ...interface{}) {
+	l.logf(InfoLevel, format, v...)
+}
+
+// Println logs the operands formatted as by fmt.Sprintln at InfoLevel.
+// As with log.Logger, the trailing newline ends the entry rather than becoming part of the message.
+func (l *Logger) Println(v ...interface{}) {
+	if !l.enabled(InfoLevel) {
+		return
+	}
+	l.writeEntry(InfoLevel, strings.TrimSuffix(fmt.Sprintln(v...), "\n"), nil)
+}
@@ -0,0 +1,61 @@
+// Package promhook provides a bolog hook exporting Prometheus metrics about written log entries.
+package promhook
+
+import (
+	"github.com/Lacolle87/bolog"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PrometheusHook counts log entries per level and the bytes written to the log file.
+// It implements bolog.Hook and bolog.WrittenHook.
+type PrometheusHook struct {
+	entries *prometheus.CounterVec
+	bytes   prometheus.Counter
+}
+
+// NewPrometheusHook creates a PrometheusHook and registers its counters with prometheus.DefaultRegisterer.
+// It panics if the counters are already registered.
+func NewPrometheusHook(namespace, subsystem string) *PrometheusHook {
+	hook, err := NewPrometheusHookWithRegisterer(prometheus.DefaultRegisterer, namespace, subsystem)
+	if err != nil {
+		panic(err)
+	}
+	return hook
+}
+
+// NewPrometheusHookWithRegisterer creates a PrometheusHook and registers its counters with registerer.
+func NewPrometheusHookWithRegisterer(registerer prometheus.Registerer, namespace, subsystem string) (*PrometheusHook, error) {
+	hook := &PrometheusHook{
+		entries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "log_entries_total",
+			Help:      "Number of log entries by level.",
+		}, []string{"level"}),
+		bytes: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "log_bytes_total",
+			Help:      "Number of bytes written to the log.",
+		}),
+	}
+	if err := registerer.Register(hook.entries); err != nil {
+		return nil, err
+	}
+	if err := registerer.Register(hook.bytes); err != nil {
+		registerer.Unregister(hook.entries)
+		return nil, err
+	}
+	return hook, nil
+}
+
+// Fire implements bolog.Hook by counting the entry under its level.
+func (h *PrometheusHook) Fire(entry bolog.Entry) error {
+	h.entries.WithLabelValues(entry.Level.String()).Inc()
+	return nil
+}
+
+// Written implements bolog.WrittenHook by adding n to the written bytes.
+func (h *PrometheusHook) Written(_ bolog.Entry, n int) {
+	h.bytes.Add(float64(n))
+}
@@ -0,0 +1,69 @@
+package bolog
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// rateLimiter is a token bucket holding up to n tokens, refilled at n tokens per period.
+type rateLimiter struct {
+	mu       sync.Mutex
+	capacity float64
+	rate     float64 // Tokens added per second
+	tokens   float64
+	last     time.Time
+
+	dropped atomic.Uint64
+}
+
+func newRateLimiter(n int, per time.Duration) *rateLimiter {
+	return &rateLimiter{
+		capacity: float64(n),
+		rate:     float64(n) / per.Seconds(),
+		tokens:   float64(n),
+		last:     time.Now(),
+	}
+}
+
+// allow takes a token from the bucket, reporting false and counting the drop if none is left.
+func (r *rateLimiter) allow() bool {
+	now := time.Now()
+	r.mu.Lock()
+	r.tokens += now.Sub(r.last).Seconds() * r.rate
+	if r.tokens > r.capacity {
+		r.tokens = r.capacity
+	}
+	r.last = now
+	ok := r.tokens >= 1
+	if ok {
+		r.tokens--
+	}
+	r.mu.Unlock()
+
+	if !ok {
+		r.dropped.Add(1)
+	}
+	return ok
+}
+
+// WithRateLimit returns a logger that writes at most n entries per period and drops the rest.
+// The limit is shared by the loggers derived from the returned one and counts entries of every level.
+// A non-positive n or per returns a logger without a limit.
+func (l *Logger) WithRateLimit(n int, per time.Duration) *Logger {
+	derived := l.derive()
+	if n <= 0 || per <= 0 {
+		derived.limiter = nil
+		return derived
+	}
+	derived.limiter = newRateLimiter(n, per)
+	return derived
+}
+
+// DroppedDueToRateLimit returns the number of entries dropped by the rate limit of the logger.
+func (l *Logger) DroppedDueToRateLimit() uint64 {
+	if l.limiter == nil {
+		return 0
+	}
+	return l.limiter.dropped.Load()
+}
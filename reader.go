@@ -0,0 +1,329 @@
+package bolog
+
+import (
+	"bufio"
+	"crypto/cipher"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrMalformedLine is returned by LogReader.Next for a line that is not a log entry.
+// Reading can continue with the next line.
+var ErrMalformedLine = errors.New("malformed log line")
+
+// LogReader parses the entries of a log file written by bolog.
+type LogReader struct {
+	file    *os.File
+	release func() // Releases the decompressor
+	r       *bufio.Reader
+	json    bool
+	times   timestampParser
+	pending string // Line read ahead while collecting a stack trace
+}
+
+// ReaderOptions describes how the log file read by NewLogReaderWithOptions was written.
+// They mirror the ConfigLogger fields of the same names.
+type ReaderOptions struct {
+	Format          string         // "text", "json" or "ndjson"; empty for "text"
+	EncryptionKey   []byte         // Key decrypting the file, nil if it is not encrypted
+	TimestampFormat string         // Layout of the timestamps, tried before the default layouts
+	Location        *time.Location // Zone of timestamps without an offset, UTC if nil
+}
+
+// readerOptions returns the ReaderOptions for files written with config.
+func readerOptions(config ConfigLogger) ReaderOptions {
+	opts := ReaderOptions{
+		Format:          config.Format,
+		TimestampFormat: config.TimestampFormat,
+		Location:        getTimezone(config.Timezone),
+	}
+	if len(config.EncryptionKey) > 0 {
+		opts.EncryptionKey = config.EncryptionKey
+	}
+	return opts
+}
+
+// NewLogReader opens the log file at path, written in format "text", "json" or "ndjson".
+// Compressed backups, ending in ".gz" or ".zst", and files written with CompressOnWrite are decompressed transparently,
+// including the current file while it is being written.
+// Timestamps are read in the default layout, RFC 3339 or as Unix milliseconds, in UTC;
+// use NewLogReaderWithOptions for files written with a custom TimestampFormat or Timezone.
+func NewLogReader(path string, format string) (*LogReader, error) {
+	return NewLogReaderWithOptions(path, ReaderOptions{Format: format})
+}
+
+// NewLogReaderWithKey is like NewLogReader for a file written with EncryptionKey set to key,
+// decrypting it transparently. A nil key reads the file unencrypted.
+func NewLogReaderWithKey(path string, format string, key []byte) (*LogReader, error) {
+	return NewLogReaderWithOptions(path, ReaderOptions{Format: format, EncryptionKey: key})
+}
+
+// NewLogReaderWithOptions is like NewLogReader for a file written with the settings in opts,
+// reading timestamps in opts.TimestampFormat and opts.Location.
+func NewLogReaderWithOptions(path string, opts ReaderOptions) (*LogReader, error) {
+	var aead cipher.AEAD
+	if opts.EncryptionKey != nil {
+		var err error
+		if aead, err = newAEAD(opts.EncryptionKey); err != nil {
+			return nil, err
+		}
+	}
+	reader := &LogReader{times: timestampParser{layout: opts.TimestampFormat, location: opts.Location}}
+	if reader.times.location == nil {
+		reader.times.location = time.UTC
+	}
+	switch strings.ToLower(opts.Format) {
+	case "", "text":
+	case "json", "ndjson":
+		reader.json = true
+	default:
+		return nil, fmt.Errorf("unsupported log format %q", opts.Format)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	reader.file = f
+	src, release, err := decompress(path, f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	reader.release = release
+	if aead != nil {
+		src = &decryptingReader{aead: aead, src: src}
+	}
+	reader.r = bufio.NewReader(src)
+	return reader, nil
+}
+
+// Next returns the next entry in the file, or io.EOF when there are no more.
+// Text entries keep their fields in Message, since they cannot be told apart from it.
+func (lr *LogReader) Next() (*Entry, error) {
+	for {
+		line, err := lr.readLine()
+		if err != nil {
+			return nil, err
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			// Skip blank lines and file headers such as DefaultFileHeader.
+			continue
+		}
+		if lr.json {
+			return parseJSONEntry(line, lr.times)
+		}
+		entry, err := parseTextEntry(line, lr.times)
+		if err != nil {
+			return nil, err
+		}
+		// Collect the indented stack trace lines following the entry.
+		var stack strings.Builder
+		for {
+			next, err := lr.readLine()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			if !strings.HasPrefix(next, "\t") {
+				lr.pending = next
+				break
+			}
+			stack.WriteString(next[1:])
+			stack.WriteByte('\n')
+		}
+		entry.Stack = stack.String()
+		return entry, nil
+	}
+}
+
+// Close closes the file.
+func (lr *LogReader) Close() error {
+	lr.release()
+	return lr.file.Close()
+}
+
+// readLine returns the next line without its line ending.
+func (lr *LogReader) readLine() (string, error) {
+	if lr.pending != "" {
+		line := lr.pending
+		lr.pending = ""
+		return line, nil
+	}
+	line, err := lr.r.ReadString('\n')
+	if err == io.EOF && line != "" {
+		err = nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// parseTextEntry parses a line written by TextFormatter, reading its timestamp with times.
+func parseTextEntry(line string, times timestampParser) (*Entry, error) {
+	end := strings.Index(line, "] -- ")
+	if !strings.HasPrefix(line, "[") || end < 0 {
+		return nil, fmt.Errorf("%w %q", ErrMalformedLine, line)
+	}
+	tokens := strings.Split(line[1:end], "] [")
+	entry := &Entry{Message: line[end+len("] -- "):]}
+
+	levelAt := -1
+	for i, token := range tokens {
+		if level, err := ParseLevel(token); err == nil && token == level.String() {
+			entry.Level = level
+			levelAt = i
+			break
+		}
+	}
+	if levelAt < 1 {
+		return nil, fmt.Errorf("%w %q", ErrMalformedLine, line)
+	}
+	if levelAt+1 < len(tokens) {
+		entry.Caller = tokens[levelAt+1]
+	}
+
+	header := tokens[:levelAt]
+	if len(header) > 1 && isDigits(header[0]) {
+		if _, ok := times.parse(header[1]); ok {
+			entry.Seq, _ = strconv.ParseUint(header[0], 10, 64)
+			header = header[1:]
+		}
+	}
+	t, ok := times.parse(header[0])
+	if !ok {
+		return nil, fmt.Errorf("%w %q: bad timestamp", ErrMalformedLine, line)
+	}
+	entry.Time = t
+	for _, token := range header[1:] {
+		if pid, ok := strings.CutPrefix(token, "pid:"); ok {
+			entry.PID, _ = strconv.Atoi(pid)
+		} else if elapsed, ok := parseElapsed(token); ok {
+			entry.Elapsed = elapsed
+		} else {
+			entry.Hostname = token
+		}
+	}
+	return entry, nil
+}
+
+// parseJSONEntry parses a line written by JSONFormatter, reading its timestamp with times.
+func parseJSONEntry(line string, times timestampParser) (*Entry, error) {
+	var raw map[string]interface{}
+	decoder := json.NewDecoder(strings.NewReader(line))
+	decoder.UseNumber()
+	if err := decoder.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("%w %q: %v", ErrMalformedLine, line, err)
+	}
+
+	// Entries written by NDJSONFormatter use short keys.
+	_, hasTime := raw["time"]
+	_, hasTS := raw["ts"]
+	short := hasTS && !hasTime
+
+	entry := &Entry{}
+	for key, value := range raw {
+		s, _ := value.(string)
+		if short {
+			switch key {
+			case "ts":
+				key = "time"
+			case "msg":
+				key = "message"
+			}
+		}
+		switch key {
+		case "time":
+			if n, ok := value.(json.Number); ok {
+				ms, _ := n.Int64()
+				entry.Time = time.UnixMilli(ms).In(times.location)
+			} else {
+				entry.Time, _ = times.parse(s)
+			}
+		case "level":
+			entry.Level, _ = ParseLevel(s)
+		case "message":
+			entry.Message = s
+		case "seq":
+			if n, ok := value.(json.Number); ok {
+				seq, _ := n.Int64()
+				entry.Seq = uint64(seq)
+			}
+		case "elapsed_ms":
+			if n, ok := value.(json.Number); ok {
+				ms, _ := n.Int64()
+				entry.Elapsed = time.Duration(ms) * time.Millisecond
+			}
+		case "hostname":
+			entry.Hostname = s
+		case "pid":
+			if n, ok := value.(json.Number); ok {
+				pid, _ := n.Int64()
+				entry.PID = int(pid)
+			}
+		case "caller":
+			entry.Caller = s
+		case "stack":
+			entry.Stack = s
+		default:
+			if entry.Fields == nil {
+				entry.Fields = Fields{}
+			}
+			entry.Fields[strings.TrimPrefix(key, "fields.")] = value
+		}
+	}
+	return entry, nil
+}
+
+// timestampParser reads the timestamps of a log file.
+type timestampParser struct {
+	layout   string         // TimestampFormat the file was written with, if any
+	location *time.Location // Zone of timestamps without an offset
+}
+
+// parse parses a timestamp in the parser's layout, then in the default layout, RFC 3339 or as Unix milliseconds.
+func (p timestampParser) parse(raw string) (time.Time, bool) {
+	layouts := []string{defaultTimestampFormat, time.RFC3339Nano}
+	if p.layout != "" && p.layout != TimestampUnixMilli {
+		layouts = append([]string{p.layout}, layouts...)
+	}
+	if isDigits(raw) {
+		if t := parseTimestampIn(raw, TimestampUnixMilli, p.location); !t.IsZero() {
+			return t, true
+		}
+	}
+	for _, layout := range layouts {
+		if t := parseTimestampIn(raw, layout, p.location); !t.IsZero() {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// parseElapsed parses an elapsed time written by formatElapsed.
+func parseElapsed(token string) (time.Duration, bool) {
+	if !strings.HasPrefix(token, "+") {
+		return 0, false
+	}
+	d, err := time.ParseDuration(token[1:])
+	return d, err == nil
+}
+
+// isDigits reports whether s is a non-empty string of decimal digits.
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
@@ -0,0 +1,140 @@
+package bolog_test
+
+import (
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/Lacolle87/bolog"
+)
+
+// testEvents are logged by the reader tests, oldest first.
+var testEvents = []bolog.LogEvent{
+	{Level: bolog.DebugLevel, Message: "cache warmed", Timestamp: time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)},
+	{Level: bolog.InfoLevel, Message: "request served", Timestamp: time.Date(2024, 3, 1, 9, 45, 10, 0, time.UTC)},
+	{Level: bolog.ErrorLevel, Message: "upstream timed out", Timestamp: time.Date(2024, 3, 1, 10, 5, 59, 0, time.UTC)},
+}
+
+// writeTestLog logs testEvents with opts to a new directory and returns the path of the log file.
+func writeTestLog(t *testing.T, opts ...bolog.Option) string {
+	t.Helper()
+	opts = append([]bolog.Option{bolog.WithLogDir(t.TempDir()), bolog.WithLevel(bolog.DebugLevel)}, opts...)
+	l := bolog.NewLogger(opts...)
+	for _, e := range testEvents {
+		l.LogEvent(e)
+	}
+	files, err := l.ListLogFiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("got %d log files, want 1", len(files))
+	}
+	return files[0].Path
+}
+
+// readAll returns every entry read from r.
+func readAll(t *testing.T, r *bolog.LogReader) []bolog.Entry {
+	t.Helper()
+	defer r.Close()
+	var entries []bolog.Entry
+	for {
+		entry, err := r.Next()
+		if err == io.EOF {
+			return entries
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		entries = append(entries, *entry)
+	}
+}
+
+func TestLogReaderRoundTrip(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+	tests := []struct {
+		name     string
+		format   string
+		timezone string
+		layout   string
+		location *time.Location
+	}{
+		{name: "text", format: "text"},
+		{name: "json", format: "json"},
+		{name: "ndjson", format: "ndjson"},
+		{name: "text unix millis", format: "text", layout: bolog.TimestampUnixMilli},
+		{name: "text in timezone", format: "text", timezone: "Europe/Paris", location: paris},
+		{name: "json in timezone", format: "json", timezone: "Europe/Paris", location: paris},
+		{name: "text custom layout", format: "text", timezone: "Europe/Paris", layout: "02/01/2006 15h04m05", location: paris},
+		{name: "json custom layout", format: "json", timezone: "Europe/Paris", layout: "02/01/2006 15h04m05", location: paris},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := []bolog.Option{bolog.WithFormat(tt.format), bolog.WithTimestampFormat(tt.layout)}
+			if tt.timezone != "" {
+				opts = append(opts, bolog.WithTimezone(tt.timezone))
+			}
+			path := writeTestLog(t, opts...)
+
+			r, err := bolog.NewLogReaderWithOptions(path, bolog.ReaderOptions{
+				Format:          tt.format,
+				TimestampFormat: tt.layout,
+				Location:        tt.location,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			entries := readAll(t, r)
+			if len(entries) != len(testEvents) {
+				t.Fatalf("read %d entries, want %d", len(entries), len(testEvents))
+			}
+			for i, got := range entries {
+				want := testEvents[i]
+				if got.Level != want.Level || got.Message != want.Message || !got.Time.Equal(want.Timestamp) {
+					t.Errorf("entry %d = %v %q at %v, want %v %q at %v",
+						i, got.Level, got.Message, got.Time, want.Level, want.Message, want.Timestamp)
+				}
+			}
+		})
+	}
+}
+
+func TestLogReaderMalformedLine(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+	}{
+		{name: "text", format: "text"},
+		{name: "json", format: "json"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// A logfmt file is not a valid text or JSON log.
+			path := writeTestLog(t, bolog.WithFormat("logfmt"))
+			r, err := bolog.NewLogReader(path, tt.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+			if _, err := r.Next(); !errors.Is(err, bolog.ErrMalformedLine) {
+				t.Errorf("Next() error = %v, want ErrMalformedLine", err)
+			}
+		})
+	}
+}
+
+func TestLogReaderUnsupportedFormat(t *testing.T) {
+	path := writeTestLog(t)
+	for _, format := range []string{"logfmt", "gelf", "csv"} {
+		if _, err := bolog.NewLogReader(path, format); err == nil {
+			t.Errorf("NewLogReader(%q) succeeded, want an error", format)
+		}
+	}
+}
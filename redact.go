@@ -0,0 +1,65 @@
+package bolog
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Redacted replaces the sensitive parts of messages masked by a redactor.
+const Redacted = "[REDACTED]"
+
+var (
+	creditCardPattern  = regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`)
+	bearerTokenPattern = regexp.MustCompile(`(?i)\bbearer\s+([A-Za-z0-9\-._~+/]+=*)`)
+)
+
+// NewRedactor returns a Transform replacing every capture group matched by patterns in the message
+// with "[REDACTED]", or the whole match for patterns without groups. Add it to a Pipeline
+// so that it runs before anything is written.
+func NewRedactor(patterns ...*regexp.Regexp) Transform {
+	return func(entry Entry) Entry {
+		for _, pattern := range patterns {
+			entry.Message = redact(entry.Message, pattern)
+		}
+		return entry
+	}
+}
+
+// NewCreditCardRedactor returns a redactor masking runs of 13 to 19 digits, optionally separated
+// by spaces or dashes, such as credit card numbers.
+func NewCreditCardRedactor() Transform {
+	return NewRedactor(creditCardPattern)
+}
+
+// NewBearerTokenRedactor returns a redactor masking the token of "Bearer <token>" credentials.
+func NewBearerTokenRedactor() Transform {
+	return NewRedactor(bearerTokenPattern)
+}
+
+// redact replaces the groups matched by pattern in s, or the whole matches if it has no groups.
+func redact(s string, pattern *regexp.Regexp) string {
+	matches := pattern.FindAllStringSubmatchIndex(s, -1)
+	if len(matches) == 0 {
+		return s
+	}
+	var b strings.Builder
+	last := 0
+	for _, match := range matches {
+		spans := match[2:]
+		if pattern.NumSubexp() == 0 {
+			spans = match[:2]
+		}
+		for i := 0; i+1 < len(spans); i += 2 {
+			start, end := spans[i], spans[i+1]
+			// Skip groups that did not participate or are nested in one already replaced.
+			if start < 0 || start < last {
+				continue
+			}
+			b.WriteString(s[last:start])
+			b.WriteString(Redacted)
+			last = end
+		}
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
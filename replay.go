@@ -0,0 +1,69 @@
+package bolog
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"time"
+)
+
+// ReplayedKey is the field set to true on the entries passed to hooks by Replay.
+const ReplayedKey = "replayed"
+
+// Replay reads the log files at files, in order, and fires every registered hook with their entries
+// at or above level logged at or after since, e.g. to ship the last hour of errors to a hook added after a deployment.
+// Entries are marked with the ReplayedKey field and are not written to the log file again.
+// Files are read with LogReader as written by the logger, in its Format, TimestampFormat and Timezone
+// and decrypted with its EncryptionKey; formats LogReader cannot parse, such as "logfmt", return an error.
+// Lines that are not log entries are skipped, but a file in which no line is one returns an error wrapping ErrMalformedLine.
+// Hook errors are reported with log.Printf, as for logged entries.
+func (l *Logger) Replay(files []string, level Level, since time.Time) error {
+	if l.core.closed.Load() {
+		return ErrLoggerClosed
+	}
+	opts := SearchOptions{Level: level, Since: since}
+	for _, path := range files {
+		if err := l.replayFile(path, opts); err != nil {
+			return fmt.Errorf("replaying %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// replayFile fires the hooks with the entries of the log file at path matching opts.
+func (l *Logger) replayFile(path string, opts SearchOptions) error {
+	reader, err := NewLogReaderWithOptions(path, readerOptions(*l.config()))
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	var parsed, malformed int
+	for {
+		entry, err := reader.Next()
+		if err == io.EOF {
+			if parsed == 0 && malformed > 0 {
+				return fmt.Errorf("%w: none of %d lines is a log entry", ErrMalformedLine, malformed)
+			}
+			return nil
+		}
+		if errors.Is(err, ErrMalformedLine) {
+			malformed++
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		parsed++
+		if !opts.match(entry) {
+			continue
+		}
+		entry.Fields = mergeFields(entry.Fields, Fields{ReplayedKey: true})
+		for _, hook := range l.hooks() {
+			if err := hook.Fire(*entry); err != nil {
+				log.Printf("Error firing log hook: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package bolog_test
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Lacolle87/bolog"
+)
+
+// recordingHook keeps the entries it is fired with.
+type recordingHook struct {
+	entries []bolog.Entry
+}
+
+func (h *recordingHook) Fire(entry bolog.Entry) error {
+	h.entries = append(h.entries, entry)
+	return nil
+}
+
+func TestReplay(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []bolog.Option
+	}{
+		{name: "text"},
+		{name: "json", opts: []bolog.Option{bolog.WithFormat("json")}},
+		{name: "ndjson", opts: []bolog.Option{bolog.WithFormat("ndjson")}},
+		{name: "timezone and layout", opts: []bolog.Option{
+			bolog.WithTimezone("America/New_York"), bolog.WithTimestampFormat("02/01/2006 15h04m05"),
+		}},
+		{name: "encrypted", opts: []bolog.Option{bolog.WithFormat("json"), bolog.WithEncryptionKey(make([]byte, 32))}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeTestLog(t, tt.opts...)
+			l := bolog.NewWriterLogger(io.Discard, tt.opts...)
+			hook := &recordingHook{}
+			l.AddHook(hook)
+
+			if err := l.Replay([]string{path}, bolog.InfoLevel, testEvents[1].Timestamp); err != nil {
+				t.Fatal(err)
+			}
+			if len(hook.entries) != 2 {
+				t.Fatalf("replayed %d entries, want 2", len(hook.entries))
+			}
+			for i, got := range hook.entries {
+				want := testEvents[i+1]
+				if got.Message != want.Message || !got.Time.Equal(want.Timestamp) {
+					t.Errorf("entry %d = %q at %v, want %q at %v", i, got.Message, got.Time, want.Message, want.Timestamp)
+				}
+				if got.Fields[bolog.ReplayedKey] != true {
+					t.Errorf("entry %d fields = %v, want %s set", i, got.Fields, bolog.ReplayedKey)
+				}
+			}
+			if n := l.CountAt(bolog.InfoLevel) + l.CountAt(bolog.ErrorLevel); n != 0 {
+				t.Errorf("replay wrote %d entries, want none", n)
+			}
+		})
+	}
+}
+
+func TestReplayErrors(t *testing.T) {
+	textLog := writeTestLog(t)
+	logfmtLog := writeTestLog(t, bolog.WithFormat("logfmt"))
+	tests := []struct {
+		name   string
+		format string
+		file   string
+		is     error
+	}{
+		{name: "missing file", file: filepath.Join(t.TempDir(), "missing.txt"), is: os.ErrNotExist},
+		{name: "unsupported format", format: "logfmt", file: logfmtLog},
+		{name: "no entries in format", format: "json", file: textLog, is: bolog.ErrMalformedLine},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := bolog.NewWriterLogger(io.Discard, bolog.WithFormat(tt.format))
+			err := l.Replay([]string{tt.file}, bolog.DebugLevel, testEvents[0].Timestamp)
+			if err == nil {
+				t.Fatal("Replay() succeeded, want an error")
+			}
+			if tt.is != nil && !errors.Is(err, tt.is) {
+				t.Errorf("Replay() error = %v, want %v", err, tt.is)
+			}
+		})
+	}
+}
@@ -0,0 +1,702 @@
+package bolog
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/cipher"
+	"errors"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/natefinch/lumberjack"
+)
+
+// megabyte is the unit of MaxSize.
+const megabyte = 1024 * 1024
+
+// rotatingFile serializes writes to the lumberjack.Logger and performs every rotation itself,
+// on size like lumberjack would and whenever the configured rotation interval has elapsed,
+// so that the logger knows when a new file starts.
+type rotatingFile struct {
+	mu       sync.Mutex
+	file     *lumberjack.Logger
+	config   ConfigLogger
+	interval string
+	location *time.Location // Location of config.Timezone
+	period   time.Time      // Start of the period the current file belongs to
+	size     int64          // Bytes in the current file, including buffered ones, -1 until read from disk
+	lines    int64          // Entry lines in the current file, valid once size is
+	buf      *bufio.Writer  // Buffer in front of file, nil if BufferSize is 0
+	gz       *gzip.Writer   // Compressor in front of file, behind buf, nil unless CompressOnWrite is set
+	gzOpen   bool           // Whether gz has data in a member that has not been closed yet
+	aead     cipher.AEAD    // Cipher sealing every write into a record, nil unless EncryptionKey is set
+	header   []byte         // Header of the formatter, written at the start of every new file
+	compress bool           // Compress backups, which lumberjack is told not to so it is known when they are done
+
+	seq      atomic.Uint64 // Last sequence number handed out in the current file
+	deadline writeDeadline // Bounds every write by WriteTimeout
+
+	mirrors       []*rotatingFile // Files in AdditionalLogDirs receiving a copy of every write
+	onMirrorError func(err error) // Called when writing to a mirror fails
+
+	onPreRotate func(file string)         // Called before every rotation, with the lock held
+	onRotate    func(event RotationEvent) // Called for every rotation, without the lock held
+	completed   []completedRotation       // Rotations done under the lock, not yet passed to onRotate
+}
+
+// completedRotation is a rotation waiting to be passed to onRotate.
+type completedRotation struct {
+	RotationEvent
+	algo string // Algorithm to compress OldFile with first, "gzip" or "zstd", empty if none
+}
+
+// newRotatingFile wraps file, named after now, with the rotation interval from config.
+// now must be in the location of config.Timezone, so that the current period matches the file name.
+func newRotatingFile(file *lumberjack.Logger, config ConfigLogger, now time.Time) *rotatingFile {
+	r := &rotatingFile{
+		file:     file,
+		config:   config,
+		interval: rotationInterval(config),
+		location: now.Location(),
+		size:     -1,
+		compress: file.Compress && !config.CompressOnWrite,
+	}
+	file.Compress = false
+	var dst io.Writer = file
+	if config.CompressOnWrite {
+		r.gz = gzip.NewWriter(file)
+		dst = r.gz
+	}
+	if config.BufferSize > 0 {
+		r.buf = bufio.NewWriterSize(dst, config.BufferSize)
+	}
+	if len(config.EncryptionKey) > 0 {
+		// openLogger has rejected invalid keys.
+		r.aead, _ = newAEAD(config.EncryptionKey)
+	}
+	if r.interval != "" {
+		r.period = periodStart(now, r.interval)
+	}
+	r.deadline.timeout.Store(int64(config.WriteTimeout))
+	return r
+}
+
+// Write implements io.Writer, rotating first if a new period has started
+// or p would not fit in the current file. With WriteTimeout it returns ErrWriteTimeout
+// if the write, including waiting for earlier ones, takes longer, leaving it to finish in the background.
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	if r.deadline.timeout.Load() > 0 {
+		p = bytes.Clone(p)
+	}
+	return r.deadline.run(func() (int, error) {
+		r.mu.Lock()
+		n, err := r.write(p)
+		completed := r.takeCompleted()
+		r.mu.Unlock()
+
+		r.notifyRotated(completed)
+		return n, err
+	})
+}
+
+// write implements Write with the lock held.
+func (r *rotatingFile) write(p []byte) (int, error) {
+	if r.interval != "" {
+		if err := r.rotateIfDue(time.Now()); err != nil {
+			return 0, err
+		}
+	}
+	if _, err := r.rotateIfFull(p); err != nil {
+		return 0, err
+	}
+	if err := r.writeHeader(); err != nil {
+		return 0, err
+	}
+	n, err := r.writeData(p)
+	r.lines += int64(bytes.Count(p[:n], newline))
+	r.mirror(p)
+	return n, err
+}
+
+// writeNumbered writes the entry rendered with the next sequence number in the current file.
+// If the entry starts a new file it is rendered again with number 1. Like Write it is bounded by WriteTimeout.
+func (r *rotatingFile) writeNumbered(render func(seq uint64) ([]byte, error)) (int, error) {
+	return r.deadline.run(func() (int, error) {
+		r.mu.Lock()
+		n, err := r.writeNumberedLocked(render)
+		completed := r.takeCompleted()
+		r.mu.Unlock()
+
+		r.notifyRotated(completed)
+		return n, err
+	})
+}
+
+// writeNumberedLocked implements writeNumbered with the lock held.
+func (r *rotatingFile) writeNumberedLocked(render func(seq uint64) ([]byte, error)) (int, error) {
+	if r.interval != "" {
+		if err := r.rotateIfDue(time.Now()); err != nil {
+			return 0, err
+		}
+	}
+	data, err := render(r.seq.Load() + 1)
+	if err != nil {
+		return 0, err
+	}
+	rotated, err := r.rotateIfFull(data)
+	if err != nil {
+		return 0, err
+	}
+	if rotated {
+		if data, err = render(1); err != nil {
+			return 0, err
+		}
+	}
+	if err := r.writeHeader(); err != nil {
+		return 0, err
+	}
+	n, err := r.writeData(data)
+	r.lines += int64(bytes.Count(data[:n], newline))
+	r.seq.Add(1)
+	r.mirror(data)
+	return n, err
+}
+
+// Flush writes buffered data to the file.
+func (r *rotatingFile) Flush() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.eachMirror((*rotatingFile).Flush)
+	return r.flush()
+}
+
+// writeFile writes p through the buffer, if any, to the file.
+// A failed write is retried WriteRetries times, WriteRetryDelay apart and within WriteTimeout,
+// resuming after the bytes already written so that nothing is written twice.
+func (r *rotatingFile) writeFile(p []byte) (int, error) {
+	var deadline time.Time
+	if r.config.WriteTimeout > 0 {
+		deadline = time.Now().Add(r.config.WriteTimeout)
+	}
+	written := 0
+	for attempt := 0; ; attempt++ {
+		n, err := r.writeOnce(p[written:])
+		written += n
+		if err == nil {
+			return written, nil
+		}
+		if attempt >= r.config.WriteRetries {
+			return written, err
+		}
+		if !deadline.IsZero() && time.Now().Add(r.config.WriteRetryDelay).After(deadline) {
+			return written, err
+		}
+		time.Sleep(r.config.WriteRetryDelay)
+	}
+}
+
+// writeOnce writes p through the buffer and the compressor, if any, to the file.
+// Without a buffer, compressed data is flushed to the file right away.
+func (r *rotatingFile) writeOnce(p []byte) (int, error) {
+	if r.gz != nil {
+		r.gzOpen = true
+	}
+	if r.buf != nil {
+		return r.buf.Write(p)
+	}
+	if r.gz == nil {
+		return r.file.Write(p)
+	}
+	n, err := r.gz.Write(p)
+	if err == nil {
+		err = r.gz.Flush()
+	}
+	return n, err
+}
+
+// create creates the current file with FilePerm if it does not exist yet, before lumberjack
+// opens it, since lumberjack creates files with mode 0644.
+func (r *rotatingFile) create() error {
+	if r.config.FilePerm == 0 {
+		return nil
+	}
+	f, err := os.OpenFile(r.file.Filename, os.O_CREATE|os.O_EXCL|os.O_WRONLY, r.config.FilePerm)
+	if errors.Is(err, fs.ErrExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// writeHeader writes the FileHeader followed by the formatter's header if nothing has been written
+// to the current file yet.
+func (r *rotatingFile) writeHeader() error {
+	if r.size != 0 {
+		return nil
+	}
+	if err := r.create(); err != nil {
+		return err
+	}
+	var header []byte
+	if r.config.FileHeader != nil {
+		if text := r.config.FileHeader(r.config); text != "" {
+			header = append(header, text...)
+			if !strings.HasSuffix(text, "\n") {
+				header = append(header, '\n')
+			}
+		}
+	}
+	header = append(header, r.header...)
+	if len(header) == 0 {
+		return nil
+	}
+	_, err := r.writeData(header)
+	return err
+}
+
+// writeData writes p to the file, sealed into a record if EncryptionKey is set, and adds the bytes
+// written to size. It returns how many bytes of p were written, all or none when encrypting.
+func (r *rotatingFile) writeData(p []byte) (int, error) {
+	if r.aead == nil {
+		n, err := r.writeFile(p)
+		r.size += int64(n)
+		return n, err
+	}
+	record, err := sealRecord(r.aead, p)
+	if err != nil {
+		return 0, err
+	}
+	n, err := r.writeFile(record)
+	r.size += int64(n)
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// setHeader sets the header written at the start of every new file.
+func (r *rotatingFile) setHeader(header []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.header = header
+	for _, m := range r.mirrors {
+		m.setHeader(header)
+	}
+}
+
+// flush writes buffered data to the file. It must be called before the file is closed or rotated.
+func (r *rotatingFile) flush() error {
+	if r.buf != nil {
+		if err := r.buf.Flush(); err != nil {
+			return err
+		}
+	}
+	if r.gz != nil && r.gzOpen {
+		return r.gz.Flush()
+	}
+	return nil
+}
+
+// closeFile flushes buffered data, ends the gzip member of CompressOnWrite and closes the file.
+// lumberjack reopens it on the next write.
+func (r *rotatingFile) closeFile() error {
+	if err := r.flush(); err != nil {
+		return err
+	}
+	if r.gz != nil && r.gzOpen {
+		err := r.gz.Close()
+		r.gz.Reset(r.file)
+		r.gzOpen = false
+		if err != nil {
+			return err
+		}
+	}
+	return r.file.Close()
+}
+
+// Close flushes buffered data and closes the file.
+func (r *rotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.eachMirror((*rotatingFile).Close)
+	return r.closeFile()
+}
+
+// open flushes buffered data and opens the current file for reading.
+// The file cannot be rotated while it is opened, and stays readable if it is rotated afterwards.
+func (r *rotatingFile) open() (*os.File, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.flush(); err != nil {
+		return nil, err
+	}
+	return os.Open(r.file.Filename)
+}
+
+// filename returns the path of the current file.
+func (r *rotatingFile) filename() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.file.Filename
+}
+
+// settings returns the configuration the file is currently rotated with.
+func (r *rotatingFile) settings() ConfigLogger {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.config
+}
+
+// nextSeq returns the next sequence number in the current file, starting at 1.
+func (r *rotatingFile) nextSeq() uint64 {
+	return r.seq.Add(1)
+}
+
+// reconfigure applies the rotation settings of config. The new LogDir and FilenameTemplate
+// are used when the next file is opened by rotateIfDue.
+func (r *rotatingFile) reconfigure(config ConfigLogger) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.file.MaxSize = config.MaxSize
+	r.file.MaxBackups = config.MaxBackups
+	r.file.MaxAge = config.MaxAge
+	r.compress = config.Compress && !r.config.CompressOnWrite
+	r.config.CompressAlgo = config.CompressAlgo
+	r.config.LogDir = config.LogDir
+	r.config.FilenameTemplate = config.FilenameTemplate
+	r.config.MaxTotalMB = config.MaxTotalMB
+	r.config.MaxLines = config.MaxLines
+	r.config.WriteRetries = config.WriteRetries
+	r.config.WriteRetryDelay = config.WriteRetryDelay
+	r.config.WriteTimeout = config.WriteTimeout
+	r.deadline.timeout.Store(int64(config.WriteTimeout))
+	for _, m := range r.mirrors {
+		mirrored := config
+		mirrored.LogDir = m.config.LogDir
+		m.reconfigure(mirrored)
+	}
+}
+
+// rotateIfDue moves to a new file when now belongs to a later period than the current file.
+// If the file name did not change, e.g. for hourly rotation, the current file is rotated instead.
+func (r *rotatingFile) rotateIfDue(now time.Time) error {
+	period := periodStart(now.In(r.location), r.interval)
+	if !period.After(r.period) {
+		return nil
+	}
+	r.period = period
+
+	return r.next()
+}
+
+// RotateNow completes the current file immediately, moving on to the file name
+// for the current date if it changed and rotating the current file otherwise.
+func (r *rotatingFile) RotateNow() error {
+	r.mu.Lock()
+	if r.interval != "" {
+		r.period = periodStart(time.Now().In(r.location), r.interval)
+	}
+	err := r.next()
+	completed := r.takeCompleted()
+	r.mu.Unlock()
+
+	r.notifyRotated(completed)
+	r.eachMirror((*rotatingFile).RotateNow)
+	return err
+}
+
+// reopen closes the current file and continues in the file at name.
+func (r *rotatingFile) reopen(name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.closeFile(); err != nil {
+		return err
+	}
+	r.file.Filename = name
+	r.size = -1
+	r.seq.Store(0)
+	r.updateSymlink()
+	return nil
+}
+
+// next starts the file named after the current date, or rotates the current file
+// if it already has that name.
+func (r *rotatingFile) next() error {
+	name := filepath.Join(r.config.LogDir, getLogFileName(r.config, time.Now().In(r.location)))
+	if name == r.file.Filename {
+		return r.rotate()
+	}
+	r.preRotate()
+	if err := r.closeFile(); err != nil {
+		return err
+	}
+	if _, err := os.Stat(r.file.Filename); err == nil {
+		event := RotationEvent{OldFile: r.file.Filename, NewFile: name, Time: time.Now()}
+		r.completed = append(r.completed, completedRotation{RotationEvent: event})
+	}
+	// lumberjack opens the new name on the next write, appending if it already exists.
+	r.file.Filename = name
+	r.size = -1
+	r.seq.Store(0)
+	r.discardStale()
+	r.archiveByDate()
+	r.updateSymlink()
+	r.enforceQuota()
+	return nil
+}
+
+// updateSymlink points the CurrentSymlink in LogDir to the current file, if configured.
+// The link is replaced atomically by renaming a new one over it. Errors are reported with log.Printf.
+func (r *rotatingFile) updateSymlink() {
+	if r.config.CurrentSymlink == "" {
+		return
+	}
+	link := filepath.Join(r.config.LogDir, r.config.CurrentSymlink)
+	target, err := filepath.Rel(filepath.Dir(link), r.file.Filename)
+	if err != nil {
+		target = r.file.Filename
+	}
+	tmp := link + ".tmp"
+	_ = os.Remove(tmp)
+	if err := os.Symlink(target, tmp); err != nil {
+		log.Printf("Error updating log symlink: %v", err)
+		return
+	}
+	if err := os.Rename(tmp, link); err != nil {
+		_ = os.Remove(tmp)
+		log.Printf("Error updating log symlink: %v", err)
+	}
+}
+
+// rotateIfFull rotates before writing p would take the file past MaxSize,
+// mirroring the check lumberjack makes so that it never rotates on its own, or past MaxLines.
+// It reports whether the file was rotated.
+func (r *rotatingFile) rotateIfFull(p []byte) (bool, error) {
+	if r.size < 0 {
+		r.size = 0
+		r.lines = 0
+		if info, err := os.Stat(r.file.Filename); err == nil {
+			r.size = info.Size()
+			r.lines = r.countLines()
+		}
+	}
+	if r.size == 0 {
+		return false, nil
+	}
+	full := r.size+int64(r.storedSize(len(p))) >= r.maxBytes()
+	if limit := r.config.MaxLines; limit > 0 && r.lines+int64(bytes.Count(p, newline)) > int64(limit) {
+		full = true
+	}
+	if !full {
+		return false, nil
+	}
+	return true, r.rotate()
+}
+
+// storedSize returns the number of bytes writing n bytes adds to the file, larger than n with EncryptionKey.
+func (r *rotatingFile) storedSize(n int) int {
+	if r.aead == nil {
+		return n
+	}
+	return recordSize(r.aead, n)
+}
+
+// newline is the separator of the lines counted for MaxLines.
+var newline = []byte{'\n'}
+
+// countLines returns the number of lines in the existing current file, for MaxLines.
+// Compressed and encrypted files, whose lines cannot be counted cheaply, count as empty.
+func (r *rotatingFile) countLines() int64 {
+	if r.config.MaxLines <= 0 || r.gz != nil || r.aead != nil {
+		return 0
+	}
+	data, err := os.ReadFile(r.file.Filename)
+	if err != nil {
+		return 0
+	}
+	return int64(bytes.Count(data, newline))
+}
+
+// rotate moves the current file aside, named like lumberjack names its backups so that
+// it still compresses and removes them, and starts a new one.
+func (r *rotatingFile) rotate() error {
+	r.preRotate()
+	if err := r.closeFile(); err != nil {
+		return err
+	}
+	if _, err := os.Stat(r.file.Filename); err == nil {
+		backup := backupName(r.file.Filename, r.backupLocation())
+		if err := os.Rename(r.file.Filename, backup); err != nil {
+			return err
+		}
+		event := RotationEvent{OldFile: backup, NewFile: r.file.Filename, Time: time.Now()}
+		rotation := completedRotation{RotationEvent: event}
+		if r.compress {
+			rotation.algo = r.config.CompressAlgo
+		}
+		r.completed = append(r.completed, rotation)
+	}
+	// lumberjack creates the new file, and removes old ones, on the next write.
+	r.size = 0
+	r.lines = 0
+	r.seq.Store(0)
+	r.enforceQuota()
+	return nil
+}
+
+// enforceQuota deletes the oldest backups until the log files take at most MaxTotalMB.
+// The current file is never deleted. Errors are reported with log.Printf.
+func (r *rotatingFile) enforceQuota() {
+	if r.config.MaxTotalMB <= 0 {
+		return
+	}
+	files, err := listLogFiles(r.config)
+	if err != nil {
+		log.Printf("Error enforcing log quota: %v", err)
+		return
+	}
+	total := totalSize(files)
+	limit := int64(r.config.MaxTotalMB) * megabyte
+	current := filepath.Clean(r.file.Filename)
+	for i := len(files) - 1; i >= 0 && total > limit; i-- {
+		if filepath.Clean(files[i].Path) == current {
+			continue
+		}
+		if err := os.Remove(files[i].Path); err != nil && !os.IsNotExist(err) {
+			log.Printf("Error enforcing log quota: %v", err)
+			continue
+		}
+		total -= files[i].Size
+	}
+}
+
+// takeCompleted returns the rotations done since the last call. The lock must be held.
+func (r *rotatingFile) takeCompleted() []completedRotation {
+	completed := r.completed
+	r.completed = nil
+	return completed
+}
+
+// preRotate calls onPreRotate with the current file, which is about to be completed.
+// The lock must be held.
+func (r *rotatingFile) preRotate() {
+	if r.onPreRotate != nil {
+		r.onPreRotate(r.file.Filename)
+	}
+}
+
+// notifyRotated passes every rotation to onRotate. The lock must not be held,
+// so that the callback may log. Backups to compress are compressed in the background,
+// like lumberjack does, and passed to onRotate with the path of the compressed file once done.
+func (r *rotatingFile) notifyRotated(completed []completedRotation) {
+	for _, rotation := range completed {
+		if rotation.algo != "" {
+			go r.compressAndNotify(rotation.RotationEvent, rotation.algo)
+		} else if r.onRotate != nil {
+			r.onRotate(rotation.RotationEvent)
+		}
+	}
+}
+
+// compressAndNotify compresses event.OldFile with algo and passes event to onRotate with the compressed path.
+// If compression fails the error is reported with log.Printf and the uncompressed path is passed.
+func (r *rotatingFile) compressAndNotify(event RotationEvent, algo string) {
+	if compressed, err := compressFile(event.OldFile, algo); err != nil {
+		log.Printf("Error compressing log file: %v", err)
+	} else {
+		event.OldFile = compressed
+		if algo == "zstd" {
+			r.pruneZstdBackups()
+		}
+	}
+	if r.onRotate != nil {
+		r.onRotate(event)
+	}
+}
+
+// backupTimeFormat is the layout of the rotation time lumberjack expects in backup names.
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// backupLocation returns the location of the rotation times in backup names:
+// that of Timezone if LocalBackupTime is set, and otherwise UTC.
+// lumberjack reads the times in the local time zone when pruning by MaxAge, which differs
+// from Timezone by less than the day MaxAge counts in.
+func (r *rotatingFile) backupLocation() *time.Location {
+	if r.file.LocalTime {
+		return r.location
+	}
+	return time.UTC
+}
+
+// backupName inserts the current time in location between the name and the extension of file,
+// as lumberjack does. If a backup with that name already exists,
+// as with several rotations within a millisecond, the time is advanced until the name is free.
+func backupName(file string, location *time.Location) string {
+	ext := filepath.Ext(file)
+	t := time.Now().In(location)
+	for {
+		name := strings.TrimSuffix(file, ext) + "-" + t.Format(backupTimeFormat) + ext
+		if !backupExists(name) {
+			return name
+		}
+		t = t.Add(time.Millisecond)
+	}
+}
+
+// backupExists reports whether a backup named name exists, compressed or not.
+func backupExists(name string) bool {
+	for _, path := range []string{name, name + ".gz", name + ".zst"} {
+		if _, err := os.Lstat(path); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// maxBytes returns the size limit of a file, using lumberjack's default of 100 megabytes if unset.
+func (r *rotatingFile) maxBytes() int64 {
+	if r.file.MaxSize <= 0 {
+		return 100 * megabyte
+	}
+	return int64(r.file.MaxSize) * megabyte
+}
+
+// periodStart returns the start of the rotation period containing t, in t's location.
+// Periods are computed on the wall clock with time.Date, so days, weeks and months
+// that are an hour shorter or longer across a DST change start at midnight all the same.
+func periodStart(t time.Time, interval string) time.Time {
+	year, month, day := t.Date()
+	switch interval {
+	case "hourly":
+		return time.Date(year, month, day, t.Hour(), 0, 0, 0, t.Location())
+	case "weekly":
+		// Weeks start on Monday.
+		offset := (int(t.Weekday()) + 6) % 7
+		return time.Date(year, month, day-offset, 0, 0, 0, 0, t.Location())
+	case "monthly":
+		return time.Date(year, month, 1, 0, 0, 0, 0, t.Location())
+	default:
+		return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+	}
+}
+
+// validRotateInterval reports whether interval is a supported RotateInterval value.
+func validRotateInterval(interval string) bool {
+	switch strings.ToLower(interval) {
+	case "", "hourly", "daily", "weekly", "monthly":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,171 @@
+package bolog
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"runtime/debug"
+	"sort"
+)
+
+var _ LeveledLogger = (*LevelRouter)(nil)
+
+// LevelRouter sends every entry to the logger routed for its level, e.g. errors to error.log
+// and everything else to app.log. Entries at ErrorLevel and above are also copied to the alert writer, if set.
+type LevelRouter struct {
+	levels  []Level // Routed levels in ascending order
+	loggers map[Level]*Logger
+	alerts  map[*Logger]*Logger // Routed loggers tee'd to the alert writer, used at ErrorLevel and above
+}
+
+// NewLevelRouter returns a LevelRouter for routes. A level without a route uses the route of the
+// closest lower level, or of the lowest routed level if there is none below it.
+// If routes is empty, or for a nil logger, the default logger at the time of the call is used, see Default.
+// Entries copied to the alert writer carry the fields the routed loggers had when the router was created.
+func NewLevelRouter(routes map[Level]*Logger) *LevelRouter {
+	if len(routes) == 0 {
+		routes = map[Level]*Logger{DebugLevel: nil}
+	}
+	r := &LevelRouter{
+		loggers: make(map[Level]*Logger, len(routes)),
+		alerts:  make(map[*Logger]*Logger, len(routes)),
+	}
+	for level, l := range routes {
+		if l == nil {
+			l = Default()
+		}
+		r.levels = append(r.levels, level)
+		r.loggers[level] = l
+		if r.alerts[l] == nil {
+			r.alerts[l] = l.Tee(nil)
+		}
+	}
+	sort.Slice(r.levels, func(i, j int) bool { return r.levels[i] < r.levels[j] })
+	return r
+}
+
+// SetAlertWriter sets the writer receiving a copy of every entry at ErrorLevel and above.
+// A nil writer disables the copies.
+func (r *LevelRouter) SetAlertWriter(w io.Writer) {
+	for _, alert := range r.alerts {
+		alert.out.(*teeWriter).attach(w)
+	}
+}
+
+// Logger returns the logger entries at level are routed to.
+func (r *LevelRouter) Logger(level Level) *Logger {
+	route := r.levels[0]
+	for _, routed := range r.levels {
+		if routed > level {
+			break
+		}
+		route = routed
+	}
+	return r.loggers[route]
+}
+
+// Logf logs a formatted message at InfoLevel.
+func (r *LevelRouter) Logf(format string, v ...interface{}) {
+	r.logf(InfoLevel, format, v...)
+}
+
+// Debugf logs a formatted message at DebugLevel.
+func (r *LevelRouter) Debugf(format string, v ...interface{}) {
+	r.logf(DebugLevel, format, v...)
+}
+
+// Infof logs a formatted message at InfoLevel.
+func (r *LevelRouter) Infof(format string, v ...interface{}) {
+	r.logf(InfoLevel, format, v...)
+}
+
+// Warnf logs a formatted message at WarnLevel.
+func (r *LevelRouter) Warnf(format string, v ...interface{}) {
+	r.logf(WarnLevel, format, v...)
+}
+
+// Errorf logs a formatted message at ErrorLevel.
+func (r *LevelRouter) Errorf(format string, v ...interface{}) {
+	r.logf(ErrorLevel, format, v...)
+}
+
+// Fatalf logs a formatted message at FatalLevel, closes every routed logger and then calls os.Exit(1).
+func (r *LevelRouter) Fatalf(format string, v ...interface{}) {
+	r.logf(FatalLevel, format, v...)
+	r.exit()
+}
+
+// LogInfo logs msg unformatted at InfoLevel.
+func (r *LevelRouter) LogInfo(msg string) {
+	if l := r.target(InfoLevel); l.enabled(InfoLevel) {
+		_ = l.writeEntry(InfoLevel, msg, nil)
+	}
+}
+
+// LogError logs err at ErrorLevel with a stack trace. A nil error is ignored.
+func (r *LevelRouter) LogError(err error) {
+	if err == nil {
+		return
+	}
+	if l := r.target(ErrorLevel); l.enabled(ErrorLevel) {
+		_ = l.write(Entry{Level: ErrorLevel, Message: err.Error(), Stack: string(debug.Stack())})
+	}
+}
+
+// LogFatal logs err at FatalLevel with a stack trace, closes every routed logger and then calls os.Exit(1).
+func (r *LevelRouter) LogFatal(err error) {
+	if err != nil {
+		if l := r.target(FatalLevel); l.enabled(FatalLevel) {
+			_ = l.write(Entry{Level: FatalLevel, Message: err.Error(), Stack: string(debug.Stack())})
+		}
+	}
+	r.exit()
+}
+
+// Close closes every routed logger and returns the errors joined. It implements io.Closer.
+func (r *LevelRouter) Close() error {
+	var errs []error
+	for _, l := range r.distinct() {
+		if err := l.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// logf writes a formatted message to the logger routed for level.
+func (r *LevelRouter) logf(level Level, format string, v ...interface{}) {
+	if l := r.target(level); l.enabled(level) {
+		_ = l.writeEntry(level, fmt.Sprintf(format, v...), nil)
+	}
+}
+
+// target returns the logger routed for level, tee'd to the alert writer for errors.
+func (r *LevelRouter) target(level Level) *Logger {
+	l := r.Logger(level)
+	if level < ErrorLevel {
+		return l
+	}
+	return r.alerts[l]
+}
+
+// distinct returns every routed logger once, even if it serves several levels.
+func (r *LevelRouter) distinct() []*Logger {
+	var loggers []*Logger
+	seen := make(map[*Logger]bool, len(r.loggers))
+	for _, level := range r.levels {
+		l := r.loggers[level]
+		if !seen[l] {
+			seen[l] = true
+			loggers = append(loggers, l)
+		}
+	}
+	return loggers
+}
+
+// exit closes every routed logger and terminates the process.
+func (r *LevelRouter) exit() {
+	_ = r.Close()
+	os.Exit(1)
+}
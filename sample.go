@@ -0,0 +1,25 @@
+package bolog
+
+import "sync/atomic"
+
+// sampler keeps every Nth entry for SampleRate.
+type sampler struct {
+	seen    atomic.Uint64
+	sampled atomic.Uint64 // Entries left out
+}
+
+// keep reports whether the entry just seen is the rate-th since the last one kept,
+// counting it as sampled out if it is not.
+func (s *sampler) keep(rate int) bool {
+	if s.seen.Add(1)%uint64(rate) == 0 {
+		return true
+	}
+	s.sampled.Add(1)
+	return false
+}
+
+// SampledCount returns the number of entries left out by SampleRate,
+// across the logger and the loggers derived from it.
+func (l *Logger) SampledCount() uint64 {
+	return l.core.sampler.sampled.Load()
+}
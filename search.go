@@ -0,0 +1,127 @@
+package bolog
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SearchOptions selects the entries returned by Search. Zero values match every entry.
+type SearchOptions struct {
+	Level           Level          // Minimum level
+	Since           time.Time      // Earliest entry time, inclusive
+	Until           time.Time      // Latest entry time, exclusive
+	MessageContains string         // Substring the message must contain
+	MessageRegexp   *regexp.Regexp // Pattern the message must match
+
+	// TimestampFormat and Location describe how the files were written, see ReaderOptions,
+	// so that entry times are compared and ordered correctly.
+	TimestampFormat string
+	Location        *time.Location // UTC if nil
+}
+
+// match reports whether entry satisfies every criterion of opts.
+func (opts SearchOptions) match(entry *Entry) bool {
+	switch {
+	case entry.Level < opts.Level:
+		return false
+	case !opts.Since.IsZero() && entry.Time.Before(opts.Since):
+		return false
+	case !opts.Until.IsZero() && !entry.Time.Before(opts.Until):
+		return false
+	case opts.MessageContains != "" && !strings.Contains(entry.Message, opts.MessageContains):
+		return false
+	case opts.MessageRegexp != nil && !opts.MessageRegexp.MatchString(entry.Message):
+		return false
+	}
+	return true
+}
+
+// Search reads every log file directly inside dirs, in text or JSON format and possibly gzip or zstd compressed,
+// and returns the entries matching opts sorted by time. Lines that are not log entries,
+// and symlinks such as CurrentSymlink, are skipped.
+func Search(dirs []string, opts SearchOptions) ([]Entry, error) {
+	var results []Entry
+	for _, dir := range dirs {
+		files, err := os.ReadDir(dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			if !file.Type().IsRegular() {
+				continue
+			}
+			path := filepath.Join(dir, file.Name())
+			format, err := sniffFormat(path)
+			if err != nil {
+				return nil, err
+			}
+			entries, err := searchFile(path, format, opts)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, entries...)
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Time.Before(results[j].Time)
+	})
+	return results, nil
+}
+
+// searchFile returns the entries of the log file at path matching opts.
+func searchFile(path, format string, opts SearchOptions) ([]Entry, error) {
+	reader, err := NewLogReaderWithOptions(path, ReaderOptions{
+		Format:          format,
+		TimestampFormat: opts.TimestampFormat,
+		Location:        opts.Location,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	var entries []Entry
+	for {
+		entry, err := reader.Next()
+		if err == io.EOF {
+			return entries, nil
+		}
+		if errors.Is(err, ErrMalformedLine) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if opts.match(entry) {
+			entries = append(entries, *entry)
+		}
+	}
+}
+
+// sniffFormat reports "json" if the first byte of the file at path, after decompression, opens an object,
+// and "text" otherwise.
+func sniffFormat(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	r, release, err := decompress(path, f)
+	if err != nil {
+		return "", err
+	}
+	defer release()
+	first, err := bufio.NewReader(r).Peek(1)
+	if err == nil && first[0] == '{' {
+		return "json", nil
+	}
+	return "text", nil
+}
@@ -0,0 +1,83 @@
+package bolog_test
+
+import (
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/Lacolle87/bolog"
+)
+
+func TestSearch(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+	textDir := filepath.Dir(writeTestLog(t))
+	jsonDir := filepath.Dir(writeTestLog(t, bolog.WithFormat("json")))
+	parisDir := filepath.Dir(writeTestLog(t, bolog.WithTimezone("Europe/Paris")))
+
+	tests := []struct {
+		name string
+		dirs []string
+		opts bolog.SearchOptions
+		want []string
+	}{
+		{
+			name: "everything, sorted by time",
+			dirs: []string{textDir, jsonDir},
+			want: []string{"cache warmed", "cache warmed", "request served", "request served", "upstream timed out", "upstream timed out"},
+		},
+		{
+			name: "minimum level",
+			dirs: []string{textDir},
+			opts: bolog.SearchOptions{Level: bolog.InfoLevel},
+			want: []string{"request served", "upstream timed out"},
+		},
+		{
+			name: "time range",
+			dirs: []string{jsonDir},
+			opts: bolog.SearchOptions{Since: testEvents[1].Timestamp, Until: testEvents[2].Timestamp},
+			want: []string{"request served"},
+		},
+		{
+			name: "message substring",
+			dirs: []string{textDir},
+			opts: bolog.SearchOptions{MessageContains: "served"},
+			want: []string{"request served"},
+		},
+		{
+			name: "message pattern",
+			dirs: []string{textDir},
+			opts: bolog.SearchOptions{MessageRegexp: regexp.MustCompile(`^(cache|upstream) `)},
+			want: []string{"cache warmed", "upstream timed out"},
+		},
+		{
+			name: "time range in timezone",
+			dirs: []string{parisDir},
+			opts: bolog.SearchOptions{Since: testEvents[1].Timestamp, Until: testEvents[2].Timestamp, Location: paris},
+			want: []string{"request served"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := bolog.Search(tt.dirs, tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, e := range entries {
+				got = append(got, e.Message)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Search() = %q, want %q", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("Search() = %q, want %q", got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package bolog
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrLoggerClosed is returned when writing an entry after Shutdown.
+var ErrLoggerClosed = errors.New("bolog: logger is shut down")
+
+// Shutdown stops the logger and the loggers derived from it from accepting new entries,
+// drains any buffered entries, waits for writes abandoned after WriteTimeout and closes the log file.
+// If ctx is done before draining completes, the file is still closed and ctx.Err() is returned;
+// if abandoned writes are still blocked then, the file is closed in the background once they return.
+func (l *Logger) Shutdown(ctx context.Context) error {
+	l.core.closed.Store(true)
+
+	drained := make(chan error, 1)
+	go func() {
+		drained <- l.flushOutput()
+	}()
+
+	var err error
+	select {
+	case err = <-drained:
+		err = errors.Join(err, l.core.file.deadline.wait(ctx))
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	if err != nil && l.core.file.deadline.stuck() {
+		// Closing would wait for the file lock held by the blocked writes.
+		go l.Close()
+		return err
+	}
+	closeErr := l.Close()
+	if err != nil {
+		return err
+	}
+	return closeErr
+}
@@ -0,0 +1,150 @@
+package bolog
+
+import (
+	"context"
+	"log/slog"
+	"path/filepath"
+	"runtime"
+	"strconv"
+)
+
+// SlogHandler returns a slog.Handler writing records through l. See NewSlogHandler.
+func (l *Logger) SlogHandler() slog.Handler {
+	return NewSlogHandler(l)
+}
+
+// NewSlogHandler returns a slog.Handler that writes records through l, honouring its minimum level.
+// Attributes become entry fields and groups become nested Fields, rendered as dotted keys
+// in text format and as nested objects in JSON format.
+func NewSlogHandler(l *Logger) slog.Handler {
+	return &slogHandler{l: l}
+}
+
+// slogHandler adapts a Logger to slog.Handler.
+type slogHandler struct {
+	l      *Logger
+	fields Fields   // Attributes added with WithAttrs, nested by group
+	groups []string // Groups opened with WithGroup, outermost first
+}
+
+// Enabled implements slog.Handler.
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.l.enabled(levelFromSlog(level))
+}
+
+// Handle implements slog.Handler. ctx becomes the entry's Context, for hooks such as those extracting trace IDs.
+func (h *slogHandler) Handle(ctx context.Context, r slog.Record) error {
+	fields := copyFields(h.fields)
+	target := groupFields(fields, h.groups)
+	r.Attrs(func(a slog.Attr) bool {
+		addAttr(target, a)
+		return true
+	})
+	pruneEmptyGroups(fields)
+
+	entry := Entry{
+		Time:    r.Time,
+		Level:   levelFromSlog(r.Level),
+		Message: r.Message,
+		Fields:  fields,
+		Context: ctx,
+	}
+	if h.l.config().CallerDepth > 0 && r.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
+		entry.Caller = filepath.Base(frame.File) + ":" + strconv.Itoa(frame.Line)
+	}
+	return h.l.write(entry)
+}
+
+// WithAttrs implements slog.Handler.
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	fields := copyFields(h.fields)
+	target := groupFields(fields, h.groups)
+	for _, a := range attrs {
+		addAttr(target, a)
+	}
+	return &slogHandler{l: h.l, fields: fields, groups: h.groups}
+}
+
+// WithGroup implements slog.Handler.
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	groups := make([]string, len(h.groups), len(h.groups)+1)
+	copy(groups, h.groups)
+	return &slogHandler{l: h.l, fields: h.fields, groups: append(groups, name)}
+}
+
+// levelFromSlog maps a slog level onto the closest bolog Level at or below it.
+func levelFromSlog(level slog.Level) Level {
+	switch {
+	case level < slog.LevelInfo:
+		return DebugLevel
+	case level < slog.LevelWarn:
+		return InfoLevel
+	case level < slog.LevelError:
+		return WarnLevel
+	default:
+		return ErrorLevel
+	}
+}
+
+// addAttr stores a in fields, turning groups into nested Fields.
+func addAttr(fields Fields, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+	if a.Value.Kind() != slog.KindGroup {
+		fields[a.Key] = a.Value.Any()
+		return
+	}
+	target := fields
+	if a.Key != "" {
+		target = groupFields(fields, []string{a.Key})
+	}
+	for _, member := range a.Value.Group() {
+		addAttr(target, member)
+	}
+}
+
+// groupFields returns the nested Fields for the group path, creating missing levels.
+func groupFields(fields Fields, groups []string) Fields {
+	for _, group := range groups {
+		nested, ok := fields[group].(Fields)
+		if !ok {
+			nested = Fields{}
+			fields[group] = nested
+		}
+		fields = nested
+	}
+	return fields
+}
+
+// copyFields returns a deep copy of fields, duplicating nested Fields.
+func copyFields(fields Fields) Fields {
+	copied := make(Fields, len(fields))
+	for key, value := range fields {
+		if nested, ok := value.(Fields); ok {
+			value = copyFields(nested)
+		}
+		copied[key] = value
+	}
+	return copied
+}
+
+// pruneEmptyGroups removes nested Fields without any attributes, as slog handlers must.
+func pruneEmptyGroups(fields Fields) {
+	for key, value := range fields {
+		if nested, ok := value.(Fields); ok {
+			pruneEmptyGroups(nested)
+			if len(nested) == 0 {
+				delete(fields, key)
+			}
+		}
+	}
+}
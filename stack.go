@@ -0,0 +1,21 @@
+package bolog
+
+import "runtime/debug"
+
+// LogError logs err at ErrorLevel followed by the stack trace of the calling goroutine.
+// A nil error is ignored.
+func (l *Logger) LogError(err error) {
+	if err == nil || !l.enabled(ErrorLevel) {
+		return
+	}
+	l.write(Entry{Level: ErrorLevel, Message: err.Error(), Stack: string(debug.Stack())})
+}
+
+// LogFatal logs err at FatalLevel followed by the stack trace of the calling goroutine,
+// flushes and closes the log file and then calls os.Exit(1).
+func (l *Logger) LogFatal(err error) {
+	if err != nil && l.enabled(FatalLevel) {
+		l.write(Entry{Level: FatalLevel, Message: err.Error(), Stack: string(debug.Stack())})
+	}
+	l.exit()
+}
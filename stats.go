@@ -0,0 +1,186 @@
+package bolog
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FileStats describes the current log file.
+type FileStats struct {
+	CurrentFile string
+	SizeBytes   int64 // Uncompressed size with CompressOnWrite
+	LineCount   int64
+	OldestEntry time.Time // Time of the first entry, zero if there is none or it cannot be parsed
+	NewestEntry time.Time // Time of the last entry, zero if there is none or it cannot be parsed
+}
+
+// Stats flushes buffered entries and reports the size, line count and time span of the current log file.
+// Entry times are parsed using the configured Format and TimestampFormat; formats other than
+// the built-in ones return an error.
+// Sizes and line counts are those of the decompressed and decrypted contents.
+// A file that has not been created yet is reported as empty.
+func (l *Logger) Stats() (FileStats, error) {
+	if err := l.core.file.Flush(); err != nil {
+		return FileStats{}, err
+	}
+	stats := FileStats{CurrentFile: l.core.file.filename()}
+	f, err := os.Open(stats.CurrentFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return stats, nil
+	}
+	if err != nil {
+		return FileStats{}, err
+	}
+	defer f.Close()
+
+	src, release, err := decompress(stats.CurrentFile, f)
+	if errors.Is(err, io.EOF) {
+		return stats, nil
+	}
+	if err != nil {
+		return FileStats{}, err
+	}
+	defer release()
+	if len(l.config().EncryptionKey) > 0 {
+		aead, err := newAEAD(l.config().EncryptionKey)
+		if err != nil {
+			return FileStats{}, err
+		}
+		src = &decryptingReader{aead: aead, src: src}
+	}
+
+	// The header row of formats such as CSV is not an entry either.
+	header := strings.TrimRight(string(formatterHeader(l.formatter())), "\r\n")
+	var first, last string
+	r := bufio.NewReader(src)
+	for {
+		line, err := r.ReadString('\n')
+		stats.SizeBytes += int64(len(line))
+		if strings.HasSuffix(line, "\n") {
+			stats.LineCount++
+		}
+		// Stack trace lines are indented and file headers commented, and neither starts an entry.
+		if line = strings.TrimRight(line, "\r\n"); line != "" && line[0] != '\t' && line[0] != '#' && line != header {
+			if first == "" {
+				first = line
+			}
+			last = line
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return FileStats{}, err
+		}
+	}
+	if stats.OldestEntry, err = l.parseEntryTime(first); err != nil {
+		return FileStats{}, err
+	}
+	if stats.NewestEntry, err = l.parseEntryTime(last); err != nil {
+		return FileStats{}, err
+	}
+	return stats, nil
+}
+
+// parseEntryTime extracts the timestamp of a line written with the configured format,
+// returning the zero time if it has none or it cannot be parsed,
+// and an error if the format is not one whose timestamps it knows how to read.
+func (l *Logger) parseEntryTime(line string) (time.Time, error) {
+	config := l.config()
+	format := strings.ToLower(config.Format)
+	var raw string
+	switch format {
+	case "", "text":
+		// Skip the sequence number, if any, then take the first bracketed value.
+		if n := strings.Index(line, "] "); n > 0 && config.SequenceNumbers {
+			line = line[n+2:]
+		}
+		end := strings.IndexByte(line, ']')
+		if !strings.HasPrefix(line, "[") || end < 0 {
+			return time.Time{}, nil
+		}
+		raw = line[1:end]
+	case "json":
+		var entry struct {
+			Time json.RawMessage `json:"time"`
+		}
+		if json.Unmarshal([]byte(line), &entry) != nil || entry.Time == nil {
+			return time.Time{}, nil
+		}
+		raw = strings.Trim(string(entry.Time), `"`)
+	case "ndjson":
+		var entry struct {
+			TS string `json:"ts"`
+		}
+		if json.Unmarshal([]byte(line), &entry) != nil {
+			return time.Time{}, nil
+		}
+		t, err := time.Parse(time.RFC3339Nano, entry.TS)
+		if err != nil {
+			return time.Time{}, nil
+		}
+		return t.In(l.core.location), nil
+	case "gelf":
+		var entry struct {
+			Timestamp float64 `json:"timestamp"` // Unix seconds with microsecond precision
+		}
+		if json.Unmarshal([]byte(line), &entry) != nil || entry.Timestamp == 0 {
+			return time.Time{}, nil
+		}
+		sec, frac := math.Modf(entry.Timestamp)
+		return time.Unix(int64(sec), int64(math.Round(frac*1e6))*int64(time.Microsecond)).In(l.core.location), nil
+	case "logfmt":
+		ts, ok := strings.CutPrefix(line, "ts=")
+		if !ok {
+			return time.Time{}, nil
+		}
+		ts, _, _ = strings.Cut(ts, " ")
+		t, err := time.Parse(time.RFC3339, strings.Trim(ts, `"`))
+		if err != nil {
+			return time.Time{}, nil
+		}
+		return t.In(l.core.location), nil
+	case "csv":
+		columns, err := csv.NewReader(strings.NewReader(line)).Read()
+		if err != nil || len(columns) == 0 {
+			return time.Time{}, nil
+		}
+		raw = columns[0]
+	default:
+		return time.Time{}, fmt.Errorf("cannot read entry times in format %q", config.Format)
+	}
+	return parseTimestamp(raw, config.TimestampFormat, config.Timezone), nil
+}
+
+// parseTimestamp is the inverse of formatTimestamp, interpreting times without a zone in timezone.
+func parseTimestamp(raw, layout, timezone string) time.Time {
+	return parseTimestampIn(raw, layout, getTimezone(timezone))
+}
+
+// parseTimestampIn is like parseTimestamp with the zone given as a location.
+func parseTimestampIn(raw, layout string, location *time.Location) time.Time {
+	if layout == TimestampUnixMilli {
+		ms, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return time.Time{}
+		}
+		return time.UnixMilli(ms).In(location)
+	}
+	if layout == "" {
+		layout = defaultTimestampFormat
+	}
+	t, err := time.ParseInLocation(layout, raw, location)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
@@ -0,0 +1,126 @@
+//go:build !windows && !plan9
+
+// Package syslogbackend provides bolog loggers that write to syslog instead of a log file.
+package syslogbackend
+
+import (
+	"fmt"
+	"log/syslog"
+	"strings"
+	"sync"
+
+	"github.com/Lacolle87/bolog"
+)
+
+// NewSyslogLogger creates a logger that sends every entry to syslog.
+// network and addr are passed to syslog.Dial, so empty values select the local syslog daemon.
+// priority is "facility.severity" such as "local0.info", or a single severity or facility name,
+// with "user" and "info" filling in the missing part.
+func NewSyslogLogger(network, addr, priority, tag string) (*bolog.Logger, error) {
+	config := bolog.DefaultConfig()
+	config.Syslog = bolog.SyslogConfig{Network: network, Addr: addr, Priority: priority, Tag: tag}
+	return NewSyslogLoggerFromConfig(config)
+}
+
+// NewSyslogLoggerFromConfig creates a logger that sends every entry to the syslog destination in config.Syslog.
+// The other fields of config apply as they do for bolog.NewLogger, except for the file settings.
+func NewSyslogLoggerFromConfig(config bolog.ConfigLogger) (*bolog.Logger, error) {
+	priority, err := ParsePriority(config.Syslog.Priority)
+	if err != nil {
+		return nil, err
+	}
+	w := &writer{network: config.Syslog.Network, addr: config.Syslog.Addr, priority: priority, tag: config.Syslog.Tag}
+	if err := w.connect(); err != nil {
+		return nil, err
+	}
+	return bolog.NewWriterLogger(w, bolog.WithConfig(config)), nil
+}
+
+// writer is a syslog connection that reconnects when a write fails,
+// since UDP connections can silently go stale when the server restarts.
+type writer struct {
+	network  string
+	addr     string
+	priority syslog.Priority
+	tag      string
+
+	mu   sync.Mutex
+	conn *syslog.Writer
+}
+
+// connect dials the syslog server. The caller must hold mu unless w is not shared yet.
+func (w *writer) connect() error {
+	conn, err := syslog.Dial(w.network, w.addr, w.priority, w.tag)
+	if err != nil {
+		return fmt.Errorf("connecting to syslog: %w", err)
+	}
+	w.conn = conn
+	return nil
+}
+
+// Write sends p as a single syslog message, reconnecting and retrying once if the write fails.
+func (w *writer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.conn != nil {
+		if n, err := w.conn.Write(p); err == nil {
+			return n, nil
+		}
+		_ = w.conn.Close()
+		w.conn = nil
+	}
+	if err := w.connect(); err != nil {
+		return 0, err
+	}
+	return w.conn.Write(p)
+}
+
+// Close closes the syslog connection.
+func (w *writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.conn == nil {
+		return nil
+	}
+	err := w.conn.Close()
+	w.conn = nil
+	return err
+}
+
+var facilities = map[string]syslog.Priority{
+	"kern": syslog.LOG_KERN, "user": syslog.LOG_USER, "mail": syslog.LOG_MAIL,
+	"daemon": syslog.LOG_DAEMON, "auth": syslog.LOG_AUTH, "syslog": syslog.LOG_SYSLOG,
+	"lpr": syslog.LOG_LPR, "news": syslog.LOG_NEWS, "uucp": syslog.LOG_UUCP,
+	"cron": syslog.LOG_CRON, "authpriv": syslog.LOG_AUTHPRIV, "ftp": syslog.LOG_FTP,
+	"local0": syslog.LOG_LOCAL0, "local1": syslog.LOG_LOCAL1, "local2": syslog.LOG_LOCAL2,
+	"local3": syslog.LOG_LOCAL3, "local4": syslog.LOG_LOCAL4, "local5": syslog.LOG_LOCAL5,
+	"local6": syslog.LOG_LOCAL6, "local7": syslog.LOG_LOCAL7,
+}
+
+var severities = map[string]syslog.Priority{
+	"emerg": syslog.LOG_EMERG, "alert": syslog.LOG_ALERT, "crit": syslog.LOG_CRIT,
+	"err": syslog.LOG_ERR, "error": syslog.LOG_ERR, "warning": syslog.LOG_WARNING,
+	"warn": syslog.LOG_WARNING, "notice": syslog.LOG_NOTICE, "info": syslog.LOG_INFO,
+	"debug": syslog.LOG_DEBUG,
+}
+
+// ParsePriority converts a priority such as "local0.info", "err" or "daemon" into a syslog.Priority.
+// An empty name selects "user.info".
+func ParsePriority(name string) (syslog.Priority, error) {
+	facility, severity := syslog.LOG_USER, syslog.LOG_INFO
+	for _, part := range strings.Split(strings.ToLower(strings.TrimSpace(name)), ".") {
+		if part == "" {
+			continue
+		}
+		if p, ok := facilities[part]; ok {
+			facility = p
+		} else if p, ok := severities[part]; ok {
+			severity = p
+		} else {
+			return 0, fmt.Errorf("unknown syslog priority %q", name)
+		}
+	}
+	return facility | severity, nil
+}
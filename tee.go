@@ -0,0 +1,66 @@
+package bolog
+
+import (
+	"errors"
+	"io"
+	"sync"
+)
+
+// Tee returns a logger that writes every entry both to l's file and to w, e.g. os.Stdout.
+// Loggers derived from the returned logger write to w as well, until Untee is called.
+func (l *Logger) Tee(w io.Writer) *Logger {
+	child := l.derive()
+	child.out = &teeWriter{primary: l.out, extra: w}
+	return child
+}
+
+// Untee detaches the writer added with Tee, so the logger only writes to its file again.
+// It is a no-op for loggers not created by Tee.
+func (l *Logger) Untee() {
+	if tee, ok := l.out.(*teeWriter); ok {
+		tee.detach()
+	}
+}
+
+// teeWriter duplicates writes to a detachable extra writer.
+type teeWriter struct {
+	primary io.Writer
+
+	mu    sync.RWMutex
+	extra io.Writer
+}
+
+// Write implements io.Writer. The extra writer is written to even if the primary write fails.
+func (t *teeWriter) Write(p []byte) (int, error) {
+	n, err := t.primary.Write(p)
+
+	t.mu.RLock()
+	extra := t.extra
+	t.mu.RUnlock()
+	if extra != nil {
+		if _, extraErr := extra.Write(p); extraErr != nil {
+			err = errors.Join(err, extraErr)
+		}
+	}
+	return n, err
+}
+
+// Flush flushes the primary writer if it buffers.
+func (t *teeWriter) Flush() error {
+	if flusher, ok := t.primary.(interface{ Flush() error }); ok {
+		return flusher.Flush()
+	}
+	return nil
+}
+
+// attach duplicates writes to w, replacing the previous extra writer.
+func (t *teeWriter) attach(w io.Writer) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.extra = w
+}
+
+// detach stops duplicating writes.
+func (t *teeWriter) detach() {
+	t.attach(nil)
+}
@@ -0,0 +1,21 @@
+package bolog
+
+// ForTenant returns a logger annotating every entry with tenantID: the text format starts
+// the message with "[tenant:ID]", ahead of any prefix, and the other formats add it as the TenantIDKey field.
+// It combines with the other derived loggers, e.g. ForTenant(id).ForRequest(ctx) annotates entries with both;
+// calling ForTenant again replaces the tenant. The returned logger writes to the same file as l.
+func (l *Logger) ForTenant(tenantID string) *Logger {
+	child := l.derive()
+	child.tenant = tenantID
+	return child
+}
+
+// tagTenant annotates entry with the tenant of the logger in the way suited to its formatter.
+func (l *Logger) tagTenant(entry Entry) Entry {
+	if l.textFormat() {
+		entry.Message = "[tenant:" + l.tenant + "] " + entry.Message
+	} else {
+		entry.Fields = mergeFields(Fields{TenantIDKey: l.tenant}, entry.Fields)
+	}
+	return entry
+}
@@ -0,0 +1,90 @@
+package bolog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrWriteTimeout is reported to OnWriteError, and returned, when a write to the log file
+// does not complete within WriteTimeout. The write goes on in the background.
+var ErrWriteTimeout = errors.New("bolog: write to log file timed out")
+
+// writeDeadline runs writes to a file in goroutines that are abandoned once WriteTimeout has passed,
+// so that a write blocked on a stuck file system, e.g. an NFS mount, does not block the caller.
+type writeDeadline struct {
+	timeout   atomic.Int64   // WriteTimeout, 0 to write in the caller's goroutine
+	writes    sync.WaitGroup // Writes running in goroutines
+	abandoned atomic.Int64   // Writes that timed out and are still running
+}
+
+// run calls write, in a goroutine if a timeout is set, and waits at most the timeout for it to return.
+// write must not use memory the caller may reuse once run returns.
+func (d *writeDeadline) run(write func() (int, error)) (int, error) {
+	timeout := time.Duration(d.timeout.Load())
+	if timeout <= 0 {
+		return write()
+	}
+
+	type result struct {
+		n   int
+		err error
+	}
+	done := make(chan result, 1)
+	var state atomic.Int32 // writeRunning, then writeFinished or writeAbandoned, whichever happens first
+	d.writes.Add(1)
+	go func() {
+		defer d.writes.Done()
+		n, err := write()
+		done <- result{n, err}
+		if !state.CompareAndSwap(writeRunning, writeFinished) {
+			d.abandoned.Add(-1)
+		}
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case res := <-done:
+		return res.n, res.err
+	case <-timer.C:
+		d.abandoned.Add(1)
+		if state.CompareAndSwap(writeRunning, writeAbandoned) {
+			return 0, fmt.Errorf("%w after %s", ErrWriteTimeout, timeout)
+		}
+		// The write finished just in time.
+		d.abandoned.Add(-1)
+		res := <-done
+		return res.n, res.err
+	}
+}
+
+// States of a write run by writeDeadline.
+const (
+	writeRunning int32 = iota
+	writeFinished
+	writeAbandoned
+)
+
+// stuck reports whether abandoned writes are still running.
+func (d *writeDeadline) stuck() bool {
+	return d.abandoned.Load() > 0
+}
+
+// wait waits for the writes running in goroutines to return, or for ctx to be done.
+func (d *writeDeadline) wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		d.writes.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,106 @@
+package bolog
+
+import (
+	"fmt"
+	"io/fs"
+	"strings"
+	"time"
+)
+
+// ValidationError lists every problem found in a ConfigLogger.
+type ValidationError struct {
+	Violations []string
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	return "invalid logger config: " + strings.Join(e.Violations, "; ")
+}
+
+// Validate checks the configuration before it is passed to SetupLogger.
+// It returns a *ValidationError listing all violations, or nil if the configuration is valid.
+func (c ConfigLogger) Validate() error {
+	var violations []string
+	if c.LogDir == "" {
+		violations = append(violations, "logDir must not be empty")
+	}
+	if c.MaxSize <= 0 {
+		violations = append(violations, fmt.Sprintf("maxsize must be greater than 0, got %d", c.MaxSize))
+	}
+	if c.MaxBackups < 0 {
+		violations = append(violations, fmt.Sprintf("maxbackups must not be negative, got %d", c.MaxBackups))
+	}
+	if c.MaxAge < 0 {
+		violations = append(violations, fmt.Sprintf("maxage must not be negative, got %d", c.MaxAge))
+	}
+	if c.CallerDepth < 0 {
+		violations = append(violations, fmt.Sprintf("callerdepth must not be negative, got %d", c.CallerDepth))
+	}
+	if c.BufferSize < 0 {
+		violations = append(violations, fmt.Sprintf("buffersize must not be negative, got %d", c.BufferSize))
+	}
+	if c.MaxTotalMB < 0 {
+		violations = append(violations, fmt.Sprintf("maxtotalmb must not be negative, got %d", c.MaxTotalMB))
+	}
+	if c.MaxLines < 0 {
+		violations = append(violations, fmt.Sprintf("maxlines must not be negative, got %d", c.MaxLines))
+	}
+	if c.SampleRate < 0 {
+		violations = append(violations, fmt.Sprintf("samplerate must not be negative, got %d", c.SampleRate))
+	}
+	if c.MaxMessageBytes < 0 {
+		violations = append(violations, fmt.Sprintf("maxmessagebytes must not be negative, got %d", c.MaxMessageBytes))
+	}
+	if c.WriteRetries < 0 {
+		violations = append(violations, fmt.Sprintf("writeretries must not be negative, got %d", c.WriteRetries))
+	}
+	if c.WriteRetryDelay < 0 {
+		violations = append(violations, fmt.Sprintf("writeretrydelay must not be negative, got %s", c.WriteRetryDelay))
+	}
+	if c.WriteTimeout < 0 {
+		violations = append(violations, fmt.Sprintf("writetimeout must not be negative, got %s", c.WriteTimeout))
+	}
+	if c.LockTimeout < 0 {
+		violations = append(violations, fmt.Sprintf("locktimeout must not be negative, got %s", c.LockTimeout))
+	}
+	if c.DedupeWindow < 0 {
+		violations = append(violations, fmt.Sprintf("dedupewindow must not be negative, got %s", c.DedupeWindow))
+	}
+	if c.CompressAlgo != "" && c.CompressAlgo != "gzip" && c.CompressAlgo != "zstd" {
+		violations = append(violations, fmt.Sprintf("compressalgo must be \"gzip\" or \"zstd\", got %q", c.CompressAlgo))
+	}
+	if !validOpenMode(c.OpenMode) {
+		violations = append(violations, fmt.Sprintf("openmode must be \"append\", \"new\" or \"rotate\", got %q", c.OpenMode))
+	}
+	if len(c.EncryptionKey) > 0 && len(c.EncryptionKey) != EncryptionKeySize {
+		violations = append(violations, fmt.Sprintf("encryptionkey must be %d bytes, got %d", EncryptionKeySize, len(c.EncryptionKey)))
+	}
+	if c.DirPerm&^fs.ModePerm != 0 {
+		violations = append(violations, fmt.Sprintf("dirperm must only contain permission bits, got %#o", uint32(c.DirPerm)))
+	}
+	if c.FilePerm&^fs.ModePerm != 0 {
+		violations = append(violations, fmt.Sprintf("fileperm must only contain permission bits, got %#o", uint32(c.FilePerm)))
+	}
+	if _, err := time.LoadLocation(c.Timezone); err != nil {
+		violations = append(violations, fmt.Sprintf("timezone %q is invalid: %v", c.Timezone, err))
+	}
+	if c.Level != "" {
+		if _, err := ParseLevel(c.Level); err != nil {
+			violations = append(violations, err.Error())
+		}
+	}
+	if !validRotateInterval(c.RotateInterval) {
+		violations = append(violations, fmt.Sprintf("unknown rotateinterval %q", c.RotateInterval))
+	}
+	if !validRotateBy(c.RotateBy) {
+		violations = append(violations, fmt.Sprintf("unknown rotateby %q", c.RotateBy))
+	}
+	if _, ok := lookupFormatter(c.Format, c.TimestampFormat); !ok {
+		violations = append(violations, fmt.Sprintf("unknown format %q", c.Format))
+	}
+
+	if len(violations) > 0 {
+		return &ValidationError{Violations: violations}
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+// Package viperconfig loads a bolog configuration from a Viper instance.
+package viperconfig
+
+import (
+	"fmt"
+
+	"github.com/Lacolle87/bolog"
+	"github.com/mitchellh/mapstructure"
+	"github.com/spf13/viper"
+)
+
+// FromViper decodes the sub-tree at key, e.g. "logger", or the whole configuration if key is empty,
+// into a ConfigLogger. Keys are the same as in configuration files and match case-insensitively;
+// durations may be given as strings such as "5s", and unset keys keep the values of bolog.DefaultConfig.
+// The result is checked with Validate, whose *bolog.ValidationError is returned if it fails.
+func FromViper(v *viper.Viper, key string) (bolog.ConfigLogger, error) {
+	if key != "" && !v.IsSet(key) {
+		return bolog.ConfigLogger{}, fmt.Errorf("viperconfig: key %q is not set", key)
+	}
+
+	config := bolog.DefaultConfig()
+	// The yaml tags skip the fields that cannot come from configuration, such as OnWriteError.
+	tagName := func(c *mapstructure.DecoderConfig) { c.TagName = "yaml" }
+	var err error
+	if key == "" {
+		err = v.Unmarshal(&config, tagName)
+	} else {
+		err = v.UnmarshalKey(key, &config, tagName)
+	}
+	if err != nil {
+		return bolog.ConfigLogger{}, fmt.Errorf("viperconfig: decoding %q: %w", key, err)
+	}
+
+	if err := config.Validate(); err != nil {
+		return bolog.ConfigLogger{}, err
+	}
+	return config, nil
+}
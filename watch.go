@@ -0,0 +1,74 @@
+package bolog
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"os/signal"
+)
+
+// ErrNoConfigFile is returned by WatchConfig and ReloadConfig for loggers not created by InitializeLoggerFromConfig.
+var ErrNoConfigFile = errors.New("bolog: logger was not initialized from a config file")
+
+// WatchConfig reloads the configuration file passed to InitializeLoggerFromConfig
+// every time the process receives SIGHUP, until ctx is done. It returns immediately;
+// reload failures are reported with log.Printf and the previous configuration is kept.
+// On platforms without SIGHUP, such as Windows and js/wasm, it does nothing; ReloadConfig can be called instead.
+func (l *Logger) WatchConfig(ctx context.Context) error {
+	if l.core.configPath == "" {
+		return ErrNoConfigFile
+	}
+	if len(reloadSignals) == 0 {
+		return nil
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, reloadSignals...)
+	go func() {
+		defer signal.Stop(signals)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-signals:
+				if err := l.ReloadConfig(); err != nil {
+					log.Printf("Error reloading log config: %v", err)
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// ReloadConfig re-reads the configuration file passed to InitializeLoggerFromConfig.
+// MaxSize, MaxLines, MaxBackups, MaxAge, Compress, CompressAlgo, MaxTotalMB, the write retry settings, Level, Format
+// and TimestampFormat apply immediately; LogDir and FilenameTemplate take effect on the next
+// time-based rotation. The reloaded configuration is then used by the logger and the loggers derived from it,
+// e.g. by Stats and Replay, except for Timezone and the settings that cannot be read from a file,
+// such as OnWriteError and EncryptionKey, which are kept.
+func (l *Logger) ReloadConfig() error {
+	if l.core.configPath == "" {
+		return ErrNoConfigFile
+	}
+	config, err := LoadLoggerConfig(l.core.configPath)
+	if err != nil {
+		return err
+	}
+	config = config.withDefaults()
+
+	l.core.reloadMu.Lock()
+	defer l.core.reloadMu.Unlock()
+	current := l.config()
+	config.Timezone = current.Timezone
+	config.OnWriteError = current.OnWriteError
+	config.FileNamer = current.FileNamer
+	config.FileHeader = current.FileHeader
+	config.EncryptionKey = current.EncryptionKey
+
+	l.core.file.reconfigure(config)
+	l.SetLevel(getLevel(config.Level))
+	l.SetFormatter(getFormatter(config))
+	l.core.config.Store(&config)
+	return nil
+}
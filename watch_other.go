@@ -0,0 +1,8 @@
+//go:build !unix
+
+package bolog
+
+import "os"
+
+// reloadSignals is empty where there is no SIGHUP, making WatchConfig a no-op.
+var reloadSignals []os.Signal
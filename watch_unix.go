@@ -0,0 +1,11 @@
+//go:build unix
+
+package bolog
+
+import (
+	"os"
+	"syscall"
+)
+
+// reloadSignals are the signals WatchConfig reloads the configuration on.
+var reloadSignals = []os.Signal{syscall.SIGHUP}
@@ -0,0 +1,119 @@
+//go:build windows
+
+// Package winevtlog provides a bolog backend writing entries to the Windows Event Log.
+package winevtlog
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/Lacolle87/bolog"
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/svc/eventlog"
+)
+
+// eventID is the ID of every event, in the range accepted by sources installed with eventlog.InstallAsEventCreate.
+const eventID = 1
+
+// EventLogBackend writes entries to the Application event log under an event source.
+// It is an io.Writer for bolog.NewWriterLogger and a bolog.Hook for copying the entries of a file logger.
+// The event type is information, warning or error after the bolog level, and the event category
+// is the level plus one, from 1 for DebugLevel to 5 for FatalLevel.
+type EventLogBackend struct {
+	mu  sync.Mutex
+	log *eventlog.Log
+}
+
+// NewEventLogBackend opens the Application event log for source, which should be registered first,
+// e.g. with eventlog.InstallAsEventCreate(source, eventlog.Error|eventlog.Warning|eventlog.Info)
+// from an elevated installer; Windows still records events of unregistered sources, without their descriptions.
+func NewEventLogBackend(source string) (*EventLogBackend, error) {
+	log, err := eventlog.Open(source)
+	if err != nil {
+		return nil, err
+	}
+	return &EventLogBackend{log: log}, nil
+}
+
+// Write reports p, a formatted entry, as one event. The level is read from the "[LEVEL]" tag
+// of the text format or the "level" key of the JSON formats, and is InfoLevel if neither is found.
+func (b *EventLogBackend) Write(p []byte) (int, error) {
+	message := string(bytes.TrimRight(p, "\r\n"))
+	if err := b.report(levelOf(message), message); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Fire implements bolog.Hook, reporting entry in the bolog text format.
+func (b *EventLogBackend) Fire(entry bolog.Entry) error {
+	data, err := bolog.TextFormatter{}.Format(entry)
+	if err != nil {
+		return err
+	}
+	return b.report(entry.Level, strings.TrimRight(string(data), "\r\n"))
+}
+
+// Close closes the event log. It implements io.Closer.
+func (b *EventLogBackend) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.log == nil {
+		return nil
+	}
+	err := b.log.Close()
+	b.log = nil
+	return err
+}
+
+// report writes message as an event of the type and category of level.
+func (b *EventLogBackend) report(level bolog.Level, message string) error {
+	text, err := syscall.UTF16PtrFromString(strings.ReplaceAll(message, "\x00", ""))
+	if err != nil {
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.log == nil {
+		return syscall.EINVAL
+	}
+	strs := []*uint16{text}
+	return windows.ReportEvent(b.log.Handle, eventType(level), category(level), eventID, 0, 1, 0, &strs[0], nil)
+}
+
+// eventType returns the event type of level.
+func eventType(level bolog.Level) uint16 {
+	switch {
+	case level >= bolog.ErrorLevel:
+		return windows.EVENTLOG_ERROR_TYPE
+	case level == bolog.WarnLevel:
+		return windows.EVENTLOG_WARNING_TYPE
+	default:
+		return windows.EVENTLOG_INFORMATION_TYPE
+	}
+}
+
+// category returns the event category of level, from 1 for DebugLevel to 5 for FatalLevel.
+func category(level bolog.Level) uint16 {
+	if level < bolog.DebugLevel || level > bolog.FatalLevel {
+		level = bolog.InfoLevel
+	}
+	return uint16(level-bolog.DebugLevel) + 1
+}
+
+// levels are the bolog levels, searched for in formatted entries by levelOf.
+var levels = []bolog.Level{bolog.DebugLevel, bolog.InfoLevel, bolog.WarnLevel, bolog.ErrorLevel, bolog.FatalLevel}
+
+// levelOf returns the level of a formatted entry, InfoLevel if it cannot be found.
+func levelOf(message string) bolog.Level {
+	lower := strings.ToLower(message)
+	for _, level := range levels {
+		name := strings.ToLower(level.String())
+		if strings.Contains(lower, "["+name+"]") || strings.Contains(lower, `"level":"`+name+`"`) {
+			return level
+		}
+	}
+	return bolog.InfoLevel
+}
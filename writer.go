@@ -0,0 +1,26 @@
+package bolog
+
+import (
+	"io"
+	"strings"
+)
+
+// TimestampedWriter returns an io.Writer that logs every write as an InfoLevel entry,
+// adding the timestamp in the configured timezone like Logf does.
+// It makes the logger usable with APIs such as log.New that only accept an io.Writer.
+func (l *Logger) TimestampedWriter() io.Writer {
+	return timestampedWriter{l}
+}
+
+// timestampedWriter is the adapter returned by Logger.TimestampedWriter.
+type timestampedWriter struct {
+	l *Logger
+}
+
+// Write implements io.Writer. A single trailing newline is dropped since entries end with their own.
+func (w timestampedWriter) Write(p []byte) (int, error) {
+	if w.l.enabled(InfoLevel) {
+		w.l.writeEntry(InfoLevel, strings.TrimSuffix(string(p), "\n"), nil)
+	}
+	return len(p), nil
+}